@@ -1,9 +1,8 @@
 package baseline
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -18,38 +17,51 @@ type Service struct {
 	api.Client
 }
 
-// InitBaselineService convenience method to initialize a `baseline.Service` instance
-func InitBaselineService(token string) *Service {
-	host := defaultBaselineHost
-	if os.Getenv("BASELINE_API_HOST") != "" {
-		host = os.Getenv("BASELINE_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the baseline api from the BASELINE_API_HOST,
+// BASELINE_API_PATH and BASELINE_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("BASELINE", defaultBaselineHost, defaultBaselinePath, defaultBaselineScheme)
+}
 
-	path := defaultBaselinePath
-	if os.Getenv("BASELIEN_API_PATH") != "" {
-		host = os.Getenv("BASELIEN_API_PATH")
+// NewService initializes a `baseline.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultBaselineScheme
-	if os.Getenv("BASELINE_API_SCHEME") != "" {
-		scheme = os.Getenv("BASELINE_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  common.StringOrNil(token),
-		},
-	}
+// NewServiceWithConfig initializes a `baseline.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitBaselineService convenience method to initialize a `baseline.Service` instance
+func InitBaselineService(token string) *Service {
+	config := ConfigFromEnv()
+	config.Token = common.StringOrNil(token)
+	return NewServiceWithConfig(config)
 }
 
 // ConfigureStack updates the global configuration on the local baseline stack
 func ConfigureStack(token string, params map[string]interface{}) error {
-	status, _, err := InitBaselineService(token).Put("config", params)
+	return ConfigureStackWithContext(context.Background(), token, params)
+}
+
+// ConfigureStackWithContext updates the global configuration on the local baseline stack
+func ConfigureStackWithContext(ctx context.Context, token string, params map[string]interface{}) error {
+	return InitBaselineService(token).ConfigureStack(ctx, params)
+}
+
+// ConfigureStack updates the global configuration on the local baseline stack
+func (s *Service) ConfigureStack(ctx context.Context, params map[string]interface{}) error {
+	status, _, err := s.PutWithContext(ctx, "config", params)
 	if err != nil {
-		return fmt.Errorf("failed to configure baseline stack; status: %v; %s", status, err.Error())
+		return fmt.Errorf("failed to configure baseline stack; status: %v; %w", status, err)
 	}
 
 	if status != 204 {
@@ -61,7 +73,18 @@ func ConfigureStack(token string, params map[string]interface{}) error {
 
 // ListWorkgroups retrieves a paginated list of baseline workgroups scoped to the given API token
 func ListWorkgroups(token, applicationID string, params map[string]interface{}) ([]*Workgroup, error) {
-	status, resp, err := InitBaselineService(token).Get("workgroups", params)
+	return ListWorkgroupsWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListWorkgroupsWithContext retrieves a paginated list of baseline workgroups scoped to the given API token
+func ListWorkgroupsWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*Workgroup, error) {
+	return InitBaselineService(token).ListWorkgroups(ctx, applicationID, params)
+}
+
+// ListWorkgroups retrieves a paginated list of baseline workgroups scoped to the given API token
+func (s *Service) ListWorkgroups(ctx context.Context, applicationID string, params map[string]interface{}) ([]*Workgroup, error) {
+	workgroups := make([]*Workgroup, 0)
+	status, err := s.GetInto(ctx, "workgroups", params, &workgroups)
 	if err != nil {
 		return nil, err
 	}
@@ -70,41 +93,60 @@ func ListWorkgroups(token, applicationID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to list baseline workgroups; status: %v", status)
 	}
 
-	workgroups := make([]*Workgroup, 0)
-	for _, item := range resp.([]interface{}) {
-		workgroup := &Workgroup{}
-		workgroupraw, _ := json.Marshal(item)
-		json.Unmarshal(workgroupraw, &workgroup)
-		workgroups = append(workgroups, workgroup)
-	}
-
 	return workgroups, nil
 }
 
+// ListWorkgroupsPager returns an *api.Pager which walks all pages of the ListWorkgroups results
+func ListWorkgroupsPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitBaselineService(token).ListWorkgroupsPager(applicationID, params)
+}
+
+// ListWorkgroupsPager returns an *api.Pager which walks all pages of the ListWorkgroups results
+func (s *Service) ListWorkgroupsPager(applicationID string, params map[string]interface{}) *api.Pager {
+	return s.Pager("workgroups", params)
+}
+
 // CreateWorkgroup initializes a new or previously-joined workgroup on the local baseline stack
 func CreateWorkgroup(token string, params map[string]interface{}) (*Workgroup, error) {
-	status, resp, err := InitBaselineService(token).Post("workgroups", params)
+	return CreateWorkgroupWithContext(context.Background(), token, params)
+}
+
+// CreateWorkgroupWithContext initializes a new or previously-joined workgroup on the local baseline stack
+func CreateWorkgroupWithContext(ctx context.Context, token string, params map[string]interface{}) (*Workgroup, error) {
+	return InitBaselineService(token).CreateWorkgroup(ctx, params)
+}
+
+// CreateWorkgroup initializes a new or previously-joined workgroup on the local baseline stack
+func (s *Service) CreateWorkgroup(ctx context.Context, params map[string]interface{}) (*Workgroup, error) {
+	workgroup := &Workgroup{}
+	status, err := s.PostInto(ctx, "workgroups", params, workgroup)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create workgroup; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to create workgroup; status: %v; %w", status, err)
 	}
 
 	if status != 200 {
 		return nil, fmt.Errorf("failed to create workgroup; status: %v", status)
 	}
 
-	workgroup := &Workgroup{}
-	workgroupraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(workgroupraw, &workgroup)
-
 	return workgroup, nil
 }
 
 // UpdateWorkgroup updates a previously-initialized workgroup on the local baseline stack
 func UpdateWorkgroup(id, token string, params map[string]interface{}) error {
+	return UpdateWorkgroupWithContext(context.Background(), id, token, params)
+}
+
+// UpdateWorkgroupWithContext updates a previously-initialized workgroup on the local baseline stack
+func UpdateWorkgroupWithContext(ctx context.Context, id, token string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateWorkgroup(ctx, id, params)
+}
+
+// UpdateWorkgroup updates a previously-initialized workgroup on the local baseline stack
+func (s *Service) UpdateWorkgroup(ctx context.Context, id string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("workgroups/%s", id)
-	status, _, err := InitBaselineService(token).Post(uri, params)
+	status, _, err := s.PostWithContext(ctx, uri, params)
 	if err != nil {
-		return fmt.Errorf("failed to update workgroup; status: %v; %s", status, err.Error())
+		return fmt.Errorf("failed to update workgroup; status: %v; %w", status, err)
 	}
 
 	if status != 204 {
@@ -116,7 +158,18 @@ func UpdateWorkgroup(id, token string, params map[string]interface{}) error {
 
 // ListWorkflows retrieves a paginated list of baseline workflows scoped to the given API token
 func ListWorkflows(token, applicationID string, params map[string]interface{}) ([]*Workflow, error) {
-	status, resp, err := InitBaselineService(token).Get("workflows", params)
+	return ListWorkflowsWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListWorkflowsWithContext retrieves a paginated list of baseline workflows scoped to the given API token
+func ListWorkflowsWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*Workflow, error) {
+	return InitBaselineService(token).ListWorkflows(ctx, applicationID, params)
+}
+
+// ListWorkflows retrieves a paginated list of baseline workflows scoped to the given API token
+func (s *Service) ListWorkflows(ctx context.Context, applicationID string, params map[string]interface{}) ([]*Workflow, error) {
+	workflows := make([]*Workflow, 0)
+	status, err := s.GetInto(ctx, "workflows", params, &workflows)
 	if err != nil {
 		return nil, err
 	}
@@ -125,38 +178,58 @@ func ListWorkflows(token, applicationID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list baseline workflows; status: %v", status)
 	}
 
-	workflows := make([]*Workflow, 0)
-	for _, item := range resp.([]interface{}) {
-		workflow := &Workflow{}
-		workflowraw, _ := json.Marshal(item)
-		json.Unmarshal(workflowraw, &workflow)
-		workflows = append(workflows, workflow)
-	}
-
 	return workflows, nil
 }
 
+// ListWorkflowsPager returns an *api.Pager which walks all pages of the ListWorkflows results
+func ListWorkflowsPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitBaselineService(token).ListWorkflowsPager(applicationID, params)
+}
+
+// ListWorkflowsPager returns an *api.Pager which walks all pages of the ListWorkflows results
+func (s *Service) ListWorkflowsPager(applicationID string, params map[string]interface{}) *api.Pager {
+	return s.Pager("workflows", params)
+}
+
 // CreateWorkflow initializes a new workflow on the local baseline stack
 func CreateWorkflow(token string, params map[string]interface{}) (*Workflow, error) {
-	status, resp, err := InitBaselineService(token).Post("workflows", params)
+	return CreateWorkflowWithContext(context.Background(), token, params)
+}
+
+// CreateWorkflowWithContext initializes a new workflow on the local baseline stack
+func CreateWorkflowWithContext(ctx context.Context, token string, params map[string]interface{}) (*Workflow, error) {
+	return InitBaselineService(token).CreateWorkflow(ctx, params)
+}
+
+// CreateWorkflow initializes a new workflow on the local baseline stack
+func (s *Service) CreateWorkflow(ctx context.Context, params map[string]interface{}) (*Workflow, error) {
+	workflow := &Workflow{}
+	status, err := s.PostInto(ctx, "workflows", params, workflow)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create workflow; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to create workflow; status: %v; %w", status, err)
 	}
 
 	if status != 200 {
 		return nil, fmt.Errorf("failed to create workflow; status: %v", status)
 	}
 
-	workflow := &Workflow{}
-	workflowraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(workflowraw, &workflow)
-
 	return workflow, nil
 }
 
 // ListWorksteps retrieves a paginated list of baseline worksteps scoped to the given API token
 func ListWorksteps(token, applicationID string, params map[string]interface{}) ([]*Workstep, error) {
-	status, resp, err := InitBaselineService(token).Get("worksteps", params)
+	return ListWorkstepsWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListWorkstepsWithContext retrieves a paginated list of baseline worksteps scoped to the given API token
+func ListWorkstepsWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*Workstep, error) {
+	return InitBaselineService(token).ListWorksteps(ctx, applicationID, params)
+}
+
+// ListWorksteps retrieves a paginated list of baseline worksteps scoped to the given API token
+func (s *Service) ListWorksteps(ctx context.Context, applicationID string, params map[string]interface{}) ([]*Workstep, error) {
+	worksteps := make([]*Workstep, 0)
+	status, err := s.GetInto(ctx, "worksteps", params, &worksteps)
 	if err != nil {
 		return nil, err
 	}
@@ -165,40 +238,59 @@ func ListWorksteps(token, applicationID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list baseline worksteps; status: %v", status)
 	}
 
-	worksteps := make([]*Workstep, 0)
-	for _, item := range resp.([]interface{}) {
-		workstep := &Workstep{}
-		workstepraw, _ := json.Marshal(item)
-		json.Unmarshal(workstepraw, &workstep)
-		worksteps = append(worksteps, workstep)
-	}
-
 	return worksteps, nil
 }
 
+// ListWorkstepsPager returns an *api.Pager which walks all pages of the ListWorksteps results
+func ListWorkstepsPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitBaselineService(token).ListWorkstepsPager(applicationID, params)
+}
+
+// ListWorkstepsPager returns an *api.Pager which walks all pages of the ListWorksteps results
+func (s *Service) ListWorkstepsPager(applicationID string, params map[string]interface{}) *api.Pager {
+	return s.Pager("worksteps", params)
+}
+
 // CreateWorkstep initializes a new workstep on the local baseline stack
 func CreateWorkstep(token string, params map[string]interface{}) (*Workstep, error) {
-	status, resp, err := InitBaselineService(token).Post("worksteps", params)
+	return CreateWorkstepWithContext(context.Background(), token, params)
+}
+
+// CreateWorkstepWithContext initializes a new workstep on the local baseline stack
+func CreateWorkstepWithContext(ctx context.Context, token string, params map[string]interface{}) (*Workstep, error) {
+	return InitBaselineService(token).CreateWorkstep(ctx, params)
+}
+
+// CreateWorkstep initializes a new workstep on the local baseline stack
+func (s *Service) CreateWorkstep(ctx context.Context, params map[string]interface{}) (*Workstep, error) {
+	workstep := &Workstep{}
+	status, err := s.PostInto(ctx, "worksteps", params, workstep)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create workstep; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to create workstep; status: %v; %w", status, err)
 	}
 
 	if status != 200 {
 		return nil, fmt.Errorf("failed to create workstep; status: %v", status)
 	}
 
-	workstep := &Workstep{}
-	workstepraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(workstepraw, &workstep)
-
 	return workstep, nil
 }
 
 // CreateObject is a generic way to baseline a business object
 func CreateObject(token string, params map[string]interface{}) (interface{}, error) {
-	status, resp, err := InitBaselineService(token).Post("objects", params)
+	return CreateObjectWithContext(context.Background(), token, params)
+}
+
+// CreateObjectWithContext is a generic way to baseline a business object
+func CreateObjectWithContext(ctx context.Context, token string, params map[string]interface{}) (interface{}, error) {
+	return InitBaselineService(token).CreateObject(ctx, params)
+}
+
+// CreateObject is a generic way to baseline a business object
+func (s *Service) CreateObject(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	status, resp, err := s.PostWithContext(ctx, "objects", params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create baseline object; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to create baseline object; status: %v; %w", status, err)
 	}
 
 	if status != 202 {
@@ -210,10 +302,20 @@ func CreateObject(token string, params map[string]interface{}) (interface{}, err
 
 // UpdateObject updates a business object
 func UpdateObject(token, id string, params map[string]interface{}) error {
+	return UpdateObjectWithContext(context.Background(), token, id, params)
+}
+
+// UpdateObjectWithContext updates a business object
+func UpdateObjectWithContext(ctx context.Context, token, id string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateObject(ctx, id, params)
+}
+
+// UpdateObject updates a business object
+func (s *Service) UpdateObject(ctx context.Context, id string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("objects/%s", id)
-	status, _, err := InitBaselineService(token).Put(uri, params)
+	status, _, err := s.PutWithContext(ctx, uri, params)
 	if err != nil {
-		return fmt.Errorf("failed to update baseline state; status: %v; %s", status, err.Error())
+		return fmt.Errorf("failed to update baseline state; status: %v; %w", status, err)
 	}
 
 	if status != 202 {
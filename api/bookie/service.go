@@ -1,9 +1,8 @@
 package bookie
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -18,37 +17,53 @@ type Service struct {
 	api.Client
 }
 
-// InitBookieService convenience method to initialize a `bookie.Service` instance
-func InitBookieService(token *string) *Service {
-	host := defaultBookieHost
-	if os.Getenv("BOOKIE_API_HOST") != "" {
-		host = os.Getenv("BOOKIE_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the bookie api from the BOOKIE_API_HOST,
+// BOOKIE_API_PATH and BOOKIE_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("BOOKIE", defaultBookieHost, defaultBookiePath, defaultBookieScheme)
+}
 
-	path := defaultBookiePath
-	if os.Getenv("BOOKIE_API_PATH") != "" {
-		path = os.Getenv("BOOKIE_API_PATH")
+// NewService initializes a `bookie.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultBookieScheme
-	if os.Getenv("BOOKIE_API_SCHEME") != "" {
-		scheme = os.Getenv("BOOKIE_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  token,
-		},
-	}
+// NewServiceWithConfig initializes a `bookie.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitBookieService convenience method to initialize a `bookie.Service` instance
+func InitBookieService(token *string) *Service {
+	config := ConfigFromEnv()
+	config.Token = token
+	return NewServiceWithConfig(config)
 }
 
 // CreatePayment attempts to create/broadcast a payment using the given params
 // FIXME-- this is a proof of concept for now...
 func CreatePayment(token string, params map[string]interface{}) (*Payment, error) {
-	status, resp, err := InitBookieService(common.StringOrNil(token)).Post("payments", params)
+	return CreatePaymentWithContext(context.Background(), token, params)
+}
+
+// CreatePaymentWithContext attempts to create/broadcast a payment using the given params
+// FIXME-- this is a proof of concept for now...
+func CreatePaymentWithContext(ctx context.Context, token string, params map[string]interface{}) (*Payment, error) {
+	return InitBookieService(common.StringOrNil(token)).CreatePayment(ctx, params)
+}
+
+// CreatePayment attempts to create/broadcast a payment using the given params
+// FIXME-- this is a proof of concept for now...
+func (s *Service) CreatePayment(ctx context.Context, params map[string]interface{}) (*Payment, error) {
+	payment := &Payment{}
+	status, err := s.PostInto(ctx, "payments", params, payment)
 	if err != nil {
 		return nil, err
 	}
@@ -57,14 +72,5 @@ func CreatePayment(token string, params map[string]interface{}) (*Payment, error
 		return nil, fmt.Errorf("failed to create payment; status: %v", status)
 	}
 
-	// FIXME...
-	payment := &Payment{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &payment)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create payment; status: %v; %s", status, err.Error())
-	}
-
 	return payment, nil
 }
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultCircuitBreakerThreshold = 5
+const defaultCircuitBreakerCooldown = time.Second * 30
+
+// ErrCircuitOpen is returned when a request is not sent because the circuit breaker of its
+// host is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker of a host
+type CircuitState int
+
+const (
+	// CircuitClosed permits requests to be sent
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects requests with ErrCircuitOpen until the cooldown has elapsed
+	CircuitOpen
+
+	// CircuitHalfOpen permits a limited number of probe requests; the circuit closes if they
+	// succeed and opens again if any fails
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// MarshalText marshals the state as its name
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// CircuitBreaker tracks the failures of the requests sent to each host and stops sending
+// requests to a host after Threshold consecutive failures, i.e. 5xx responses, timeouts and
+// other transport errors. Once Cooldown has elapsed, up to HalfOpenProbes requests are
+// sent to probe the host. A single breaker may be shared by many clients.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures which opens the circuit
+	Threshold int
+
+	// Cooldown is the time for which the circuit remains open before probes are permitted
+	Cooldown time.Duration
+
+	// HalfOpenProbes is the number of concurrent probe requests permitted while half-open
+	HalfOpenProbes int
+
+	circuits map[string]*circuit
+	mutex    sync.Mutex
+}
+
+// CircuitStatus describes the circuit breaker of a host
+type CircuitStatus struct {
+	Host     string       `json:"host"`
+	State    CircuitState `json:"state"`
+	Failures int          `json:"failures"`
+	OpenedAt *time.Time   `json:"opened_at,omitempty"`
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// NewCircuitBreaker returns a *CircuitBreaker which opens after threshold consecutive failures
+// and permits a single probe request after each cooldown
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold:      threshold,
+		Cooldown:       cooldown,
+		HalfOpenProbes: 1,
+		circuits:       map[string]*circuit{},
+	}
+}
+
+// State returns the state of the circuit breaker of the given host
+func (b *CircuitBreaker) State(host string) CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[host]
+	if !ok {
+		return CircuitClosed
+	}
+	return b.currentState(c)
+}
+
+// Status returns the status of the circuit breaker of each host to which a request has been
+// sent, for use in health checks
+func (b *CircuitBreaker) Status() []*CircuitStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := make([]*CircuitStatus, 0, len(b.circuits))
+	for host, c := range b.circuits {
+		s := &CircuitStatus{
+			Host:     host,
+			State:    b.currentState(c),
+			Failures: c.failures,
+		}
+		if c.state != CircuitClosed {
+			openedAt := c.openedAt
+			s.OpenedAt = &openedAt
+		}
+		status = append(status, s)
+	}
+	return status
+}
+
+// Healthy returns true if no circuit is open
+func (b *CircuitBreaker) Healthy() bool {
+	for _, status := range b.Status() {
+		if status.State == CircuitOpen {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset closes the circuit breakers of all hosts
+func (b *CircuitBreaker) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.circuits = map[string]*circuit{}
+}
+
+// allow returns nil if a request may be sent to the given host; the returned bool is true
+// when the request is a half-open probe
+func (b *CircuitBreaker) allow(host string) (bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.circuit(host)
+	switch b.currentState(c) {
+	case CircuitOpen:
+		return false, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if c.probes >= b.halfOpenProbes() {
+			return false, ErrCircuitOpen
+		}
+		c.state = CircuitHalfOpen
+		c.probes++
+		return true, nil
+	}
+	return false, nil
+}
+
+// record records the outcome of a request sent to the given host
+func (b *CircuitBreaker) record(host string, probe, failed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c := b.circuit(host)
+	if probe && c.probes > 0 {
+		c.probes--
+	}
+
+	if !failed {
+		c.state = CircuitClosed
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.state == CircuitHalfOpen || c.failures >= b.threshold() {
+		c.state = CircuitOpen
+		c.openedAt = time.Now()
+		c.probes = 0
+	}
+}
+
+// release releases the probe of a request which was canceled by its caller, recording no outcome
+func (b *CircuitBreaker) release(host string, probe bool) {
+	if !probe {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if c := b.circuit(host); c.probes > 0 {
+		c.probes--
+	}
+}
+
+// currentState returns the state of the circuit, which is half-open once an open circuit's
+// cooldown has elapsed
+func (b *CircuitBreaker) currentState(c *circuit) CircuitState {
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.cooldown() {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+func (b *CircuitBreaker) circuit(host string) *circuit {
+	if b.circuits == nil {
+		b.circuits = map[string]*circuit{}
+	}
+
+	c, ok := b.circuits[host]
+	if !ok {
+		c = &circuit{}
+		b.circuits[host] = c
+	}
+	return c
+}
+
+func (b *CircuitBreaker) threshold() int {
+	if b.Threshold < 1 {
+		return defaultCircuitBreakerThreshold
+	}
+	return b.Threshold
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown <= 0 {
+		return defaultCircuitBreakerCooldown
+	}
+	return b.Cooldown
+}
+
+func (b *CircuitBreaker) halfOpenProbes() int {
+	if b.HalfOpenProbes < 1 {
+		return 1
+	}
+	return b.HalfOpenProbes
+}
+
+// middleware returns middleware which rejects requests to hosts whose circuit is open and
+// records the outcome of each attempt
+func (b *CircuitBreaker) middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			host := req.URL.Host
+			probe, err := b.allow(host)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			if errors.Is(err, context.Canceled) {
+				b.release(host, probe)
+				return resp, err
+			}
+
+			b.record(host, probe, err != nil || resp.StatusCode >= 500)
+			return resp, err
+		})
+	}
+}
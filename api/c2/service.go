@@ -1,9 +1,10 @@
 package c2
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -18,37 +19,51 @@ type Service struct {
 	api.Client
 }
 
-// InitC2Service convenience method to initialize an `c2.Service` instance
-func InitC2Service(token string) *Service {
-	host := defaultC2Host
-	if os.Getenv("C2_API_HOST") != "" {
-		host = os.Getenv("C2_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the c2 api from the C2_API_HOST,
+// C2_API_PATH and C2_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("C2", defaultC2Host, defaultC2Path, defaultC2Scheme)
+}
 
-	path := defaultC2Path
-	if os.Getenv("C2_API_PATH") != "" {
-		host = os.Getenv("C2_API_PATH")
+// NewService initializes an `c2.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultC2Scheme
-	if os.Getenv("C2_API_SCHEME") != "" {
-		scheme = os.Getenv("C2_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  common.StringOrNil(token),
-		},
-	}
+// NewServiceWithConfig initializes an `c2.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitC2Service convenience method to initialize an `c2.Service` instance
+func InitC2Service(token string) *Service {
+	config := ConfigFromEnv()
+	config.Token = common.StringOrNil(token)
+	return NewServiceWithConfig(config)
 }
 
 // ListNodes list nodes for the given authorization scope
 func ListNodes(token string, params map[string]interface{}) ([]*Node, error) {
+	return ListNodesWithContext(context.Background(), token, params)
+}
+
+// ListNodesWithContext list nodes for the given authorization scope
+func ListNodesWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Node, error) {
+	return InitC2Service(token).ListNodes(ctx, params)
+}
+
+// ListNodes list nodes for the given authorization scope
+func (s *Service) ListNodes(ctx context.Context, params map[string]interface{}) ([]*Node, error) {
 	uri := fmt.Sprintf("nodes")
-	status, resp, err := InitC2Service(token).Get(uri, params)
+	nodes := make([]*Node, 0)
+	status, err := s.GetInto(ctx, uri, params, &nodes)
 	if err != nil {
 		return nil, err
 	}
@@ -57,21 +72,35 @@ func ListNodes(token string, params map[string]interface{}) ([]*Node, error) {
 		return nil, fmt.Errorf("failed to list nodes; status: %v", status)
 	}
 
-	nodes := make([]*Node, 0)
-	for _, item := range resp.([]interface{}) {
-		node := &Node{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &node)
-		nodes = append(nodes, node)
-	}
-
 	return nodes, nil
 }
 
+// ListNodesPager returns an *api.Pager which walks all pages of the ListNodes results
+func ListNodesPager(token string, params map[string]interface{}) *api.Pager {
+	return InitC2Service(token).ListNodesPager(params)
+}
+
+// ListNodesPager returns an *api.Pager which walks all pages of the ListNodes results
+func (s *Service) ListNodesPager(params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("nodes")
+	return s.Pager(uri, params)
+}
+
 // CreateNode creates and deploys a new node for the given authorization scope
 func CreateNode(token string, params map[string]interface{}) (*Node, error) {
+	return CreateNodeWithContext(context.Background(), token, params)
+}
+
+// CreateNodeWithContext creates and deploys a new node for the given authorization scope
+func CreateNodeWithContext(ctx context.Context, token string, params map[string]interface{}) (*Node, error) {
+	return InitC2Service(token).CreateNode(ctx, params)
+}
+
+// CreateNode creates and deploys a new node for the given authorization scope
+func (s *Service) CreateNode(ctx context.Context, params map[string]interface{}) (*Node, error) {
 	uri := fmt.Sprintf("nodes")
-	status, resp, err := InitC2Service(token).Post(uri, params)
+	node := &Node{}
+	status, err := s.PostInto(ctx, uri, params, node)
 	if err != nil {
 		return nil, err
 	}
@@ -80,21 +109,24 @@ func CreateNode(token string, params map[string]interface{}) (*Node, error) {
 		return nil, fmt.Errorf("failed to create node; status: %v", status)
 	}
 
-	// FIXME...
-	node := &Node{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &node)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create node; status: %v; %s", status, err.Error())
-	}
-
 	return node, nil
 }
 
 // GetNodeDetails fetches details for the given node
 func GetNodeDetails(token, nodeID string, params map[string]interface{}) (*Node, error) {
+	return GetNodeDetailsWithContext(context.Background(), token, nodeID, params)
+}
+
+// GetNodeDetailsWithContext fetches details for the given node
+func GetNodeDetailsWithContext(ctx context.Context, token, nodeID string, params map[string]interface{}) (*Node, error) {
+	return InitC2Service(token).GetNodeDetails(ctx, nodeID, params)
+}
+
+// GetNodeDetails fetches details for the given node
+func (s *Service) GetNodeDetails(ctx context.Context, nodeID string, params map[string]interface{}) (*Node, error) {
 	uri := fmt.Sprintf("nodes/%s", nodeID)
-	status, resp, err := InitC2Service(token).Get(uri, params)
+	node := &Node{}
+	status, err := s.GetInto(ctx, uri, params, node)
 	if err != nil {
 		return nil, err
 	}
@@ -103,21 +135,24 @@ func GetNodeDetails(token, nodeID string, params map[string]interface{}) (*Node,
 		return nil, fmt.Errorf("failed to fetch node details; status: %v", status)
 	}
 
-	// FIXME...
-	node := &Node{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &node)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch node details; status: %v; %s", status, err.Error())
-	}
-
 	return node, nil
 }
 
 // EnrichNode fetches provider (aws/azure) details for the given node
 func EnrichNode(token, nodeID string, params map[string]interface{}) (*Node, error) {
+	return EnrichNodeWithContext(context.Background(), token, nodeID, params)
+}
+
+// EnrichNodeWithContext fetches provider (aws/azure) details for the given node
+func EnrichNodeWithContext(ctx context.Context, token, nodeID string, params map[string]interface{}) (*Node, error) {
+	return InitC2Service(token).EnrichNode(ctx, nodeID, params)
+}
+
+// EnrichNode fetches provider (aws/azure) details for the given node
+func (s *Service) EnrichNode(ctx context.Context, nodeID string, params map[string]interface{}) (*Node, error) {
 	uri := fmt.Sprintf("nodes/%s/enrich", nodeID)
-	status, resp, err := InitC2Service(token).Get(uri, params)
+	node := &Node{}
+	status, err := s.GetInto(ctx, uri, params, node)
 	if err != nil {
 		return nil, err
 	}
@@ -126,21 +161,24 @@ func EnrichNode(token, nodeID string, params map[string]interface{}) (*Node, err
 		return nil, fmt.Errorf("failed to enrich node details; status: %v", status)
 	}
 
-	// FIXME...
-	node := &Node{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &node)
-	if err != nil {
-		return nil, fmt.Errorf("failed to enrich node details; status: %v; %s", status, err.Error())
-	}
-
 	return node, nil
 }
 
 // GetNodeLogs fetches the logs for the given node
 func GetNodeLogs(token, nodeID string, params map[string]interface{}) (*NodeLogsResponse, error) {
+	return GetNodeLogsWithContext(context.Background(), token, nodeID, params)
+}
+
+// GetNodeLogsWithContext fetches the logs for the given node
+func GetNodeLogsWithContext(ctx context.Context, token, nodeID string, params map[string]interface{}) (*NodeLogsResponse, error) {
+	return InitC2Service(token).GetNodeLogs(ctx, nodeID, params)
+}
+
+// GetNodeLogs fetches the logs for the given node
+func (s *Service) GetNodeLogs(ctx context.Context, nodeID string, params map[string]interface{}) (*NodeLogsResponse, error) {
 	uri := fmt.Sprintf("nodes/%s/logs", nodeID)
-	status, resp, err := InitC2Service(token).Get(uri, params)
+	logsResponse := &NodeLogsResponse{}
+	status, err := s.GetInto(ctx, uri, params, logsResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -149,21 +187,125 @@ func GetNodeLogs(token, nodeID string, params map[string]interface{}) (*NodeLogs
 		return nil, fmt.Errorf("failed to fetch node logs; status: %v", status)
 	}
 
-	// FIXME...
+	return logsResponse, nil
+}
+
+// StreamNodeLogs invokes fn with each log of the given node as it is read; when the logs
+// are not streamed as newline-delimited JSON or server-sent events, pages of logs are fetched
+// until no next_token is returned. Streaming stops at the first error returned by fn.
+func StreamNodeLogs(token, nodeID string, params map[string]interface{}, fn func(*NodeLog) error) error {
+	return StreamNodeLogsWithContext(context.Background(), token, nodeID, params, fn)
+}
+
+// StreamNodeLogsWithContext invokes fn with each log of the given node as it is read; see StreamNodeLogs
+func StreamNodeLogsWithContext(ctx context.Context, token, nodeID string, params map[string]interface{}, fn func(*NodeLog) error) error {
+	return InitC2Service(token).StreamNodeLogs(ctx, nodeID, params, fn)
+}
+
+// StreamNodeLogs invokes fn with each log of the given node as it is read; see StreamNodeLogs
+func (s *Service) StreamNodeLogs(ctx context.Context, nodeID string, params map[string]interface{}, fn func(*NodeLog) error) error {
+	uri := fmt.Sprintf("nodes/%s/logs", nodeID)
+	accept := fmt.Sprintf("%s, %s, application/json", api.ContentTypeNDJSON, api.ContentTypeEventStream)
+
+	query := map[string]interface{}{}
+	for key, val := range params {
+		query[key] = val
+	}
+
+	for {
+		stream, err := s.GetStream(ctx, uri, query, accept)
+		if err != nil {
+			return fmt.Errorf("failed to stream node logs; %w", err)
+		}
+
+		nextToken, err := readNodeLogs(stream, fn)
+		stream.Close()
+		if err != nil || nextToken == nil {
+			return err
+		}
+
+		query["next_token"] = *nextToken
+	}
+}
+
+// readNodeLogs invokes fn with each log read from the given stream; the next_token is
+// returned when the stream is an ordinary page of logs
+func readNodeLogs(stream *api.Stream, fn func(*NodeLog) error) (*string, error) {
+	switch stream.ContentType() {
+	case api.ContentTypeNDJSON:
+		decoder := stream.NDJSON()
+		for {
+			log := &NodeLog{}
+			err := decoder.Decode(log)
+			if err == io.EOF {
+				return nil, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to decode node log; %w", err)
+			}
+
+			err = fn(log)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case api.ContentTypeEventStream:
+		decoder := stream.Events()
+		for {
+			evt, err := decoder.Next()
+			if err == io.EOF {
+				return nil, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to read node log event; %w", err)
+			}
+
+			log := &NodeLog{}
+			err = evt.Unmarshal(log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode node log; %w", err)
+			}
+
+			err = fn(log)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	logsResponse := &NodeLogsResponse{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &logsResponse)
+	err := json.NewDecoder(stream).Decode(logsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch node logs; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to decode node logs; %w", err)
 	}
 
-	return logsResponse, nil
+	for _, log := range logsResponse.Logs {
+		err = fn(log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(logsResponse.Logs) == 0 || logsResponse.NextToken == nil || *logsResponse.NextToken == "" {
+		return nil, nil
+	}
+	return logsResponse.NextToken, nil
 }
 
 // DeleteNode undeploys and deletes the given node
 func DeleteNode(token, nodeID string) (*Node, error) {
+	return DeleteNodeWithContext(context.Background(), token, nodeID)
+}
+
+// DeleteNodeWithContext undeploys and deletes the given node
+func DeleteNodeWithContext(ctx context.Context, token, nodeID string) (*Node, error) {
+	return InitC2Service(token).DeleteNode(ctx, nodeID)
+}
+
+// DeleteNode undeploys and deletes the given node
+func (s *Service) DeleteNode(ctx context.Context, nodeID string) (*Node, error) {
 	uri := fmt.Sprintf("nodes/%s", nodeID)
-	status, resp, err := InitC2Service(token).Delete(uri)
+	node := &Node{}
+	status, err := s.DeleteInto(ctx, uri, node)
 	if err != nil {
 		return nil, err
 	}
@@ -172,19 +314,23 @@ func DeleteNode(token, nodeID string) (*Node, error) {
 		return nil, fmt.Errorf("failed to delete node; status: %v", status)
 	}
 
-	node := &Node{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &node)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch node after delete; status: %v; %s", status, err.Error())
-	}
-
 	return node, nil
 }
 
 // ListLoadBalancers list load balancers for the given authorization scope
 func ListLoadBalancers(token string, params map[string]interface{}) ([]*LoadBalancer, error) {
-	status, resp, err := InitC2Service(token).Get("load_balancers", params)
+	return ListLoadBalancersWithContext(context.Background(), token, params)
+}
+
+// ListLoadBalancersWithContext list load balancers for the given authorization scope
+func ListLoadBalancersWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*LoadBalancer, error) {
+	return InitC2Service(token).ListLoadBalancers(ctx, params)
+}
+
+// ListLoadBalancers list load balancers for the given authorization scope
+func (s *Service) ListLoadBalancers(ctx context.Context, params map[string]interface{}) ([]*LoadBalancer, error) {
+	balancers := make([]*LoadBalancer, 0)
+	status, err := s.GetInto(ctx, "load_balancers", params, &balancers)
 	if err != nil {
 		return nil, err
 	}
@@ -193,20 +339,33 @@ func ListLoadBalancers(token string, params map[string]interface{}) ([]*LoadBala
 		return nil, fmt.Errorf("failed to list load balancers; status: %v", status)
 	}
 
-	balancers := make([]*LoadBalancer, 0)
-	for _, item := range resp.([]interface{}) {
-		balancer := &LoadBalancer{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &balancer)
-		balancers = append(balancers, balancer)
-	}
-
 	return balancers, nil
 }
 
+// ListLoadBalancersPager returns an *api.Pager which walks all pages of the ListLoadBalancers results
+func ListLoadBalancersPager(token string, params map[string]interface{}) *api.Pager {
+	return InitC2Service(token).ListLoadBalancersPager(params)
+}
+
+// ListLoadBalancersPager returns an *api.Pager which walks all pages of the ListLoadBalancers results
+func (s *Service) ListLoadBalancersPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("load_balancers", params)
+}
+
 // CreateLoadBalancer creates and deploys a new load balancer for the given authorization scope
 func CreateLoadBalancer(token string, params map[string]interface{}) (*LoadBalancer, error) {
-	status, resp, err := InitC2Service(token).Post("load_balancers", params)
+	return CreateLoadBalancerWithContext(context.Background(), token, params)
+}
+
+// CreateLoadBalancerWithContext creates and deploys a new load balancer for the given authorization scope
+func CreateLoadBalancerWithContext(ctx context.Context, token string, params map[string]interface{}) (*LoadBalancer, error) {
+	return InitC2Service(token).CreateLoadBalancer(ctx, params)
+}
+
+// CreateLoadBalancer creates and deploys a new load balancer for the given authorization scope
+func (s *Service) CreateLoadBalancer(ctx context.Context, params map[string]interface{}) (*LoadBalancer, error) {
+	balancer := &LoadBalancer{}
+	status, err := s.PostInto(ctx, "load_balancers", params, balancer)
 	if err != nil {
 		return nil, err
 	}
@@ -215,21 +374,23 @@ func CreateLoadBalancer(token string, params map[string]interface{}) (*LoadBalan
 		return nil, fmt.Errorf("failed to create load balancer; status: %v", status)
 	}
 
-	// FIXME...
-	balancer := &LoadBalancer{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &balancer)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create load balancer; status: %v; %s", status, err.Error())
-	}
-
 	return balancer, nil
 }
 
 // DeleteLoadBalancer undeploys and deletes the given load balancer
 func DeleteLoadBalancer(token, loadBalancerID string) error {
+	return DeleteLoadBalancerWithContext(context.Background(), token, loadBalancerID)
+}
+
+// DeleteLoadBalancerWithContext undeploys and deletes the given load balancer
+func DeleteLoadBalancerWithContext(ctx context.Context, token, loadBalancerID string) error {
+	return InitC2Service(token).DeleteLoadBalancer(ctx, loadBalancerID)
+}
+
+// DeleteLoadBalancer undeploys and deletes the given load balancer
+func (s *Service) DeleteLoadBalancer(ctx context.Context, loadBalancerID string) error {
 	uri := fmt.Sprintf("load_balancers/%s", loadBalancerID)
-	status, _, err := InitC2Service(token).Delete(uri)
+	status, _, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
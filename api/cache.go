@@ -0,0 +1,371 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultMaxCacheableSize = 4 << 20
+
+// ResponseCache stores the responses cached by a client; implementations must be safe for
+// concurrent use. *LRUCache is an in-memory implementation; a CachedResponse may be marshaled
+// as JSON for storage in a shared backend.
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+	Delete(key string)
+}
+
+// CachedResponse is a response stored in a ResponseCache
+type CachedResponse struct {
+	Status   int               `json:"status"`
+	Header   http.Header       `json:"header"`
+	Body     []byte            `json:"body"`
+	Vary     map[string]string `json:"vary,omitempty"`
+	StoredAt time.Time         `json:"stored_at"`
+}
+
+// cacheControl is a parsed Cache-Control header
+type cacheControl map[string]string
+
+func parseCacheControl(header http.Header) cacheControl {
+	cc := cacheControl{}
+	for _, val := range header["Cache-Control"] {
+		for _, directive := range strings.Split(val, ",") {
+			directive = strings.TrimSpace(directive)
+			if directive == "" {
+				continue
+			}
+			name, arg := directive, ""
+			if i := strings.Index(directive, "="); i >= 0 {
+				name, arg = directive[:i], strings.Trim(directive[i+1:], `"`)
+			}
+			cc[strings.ToLower(name)] = arg
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+// cacheMiddleware returns middleware which serves GET requests from the given cache in
+// accordance with RFC 7234 as a private cache: fresh responses are served without a request,
+// stale responses having an ETag or Last-Modified header are revalidated using a conditional
+// request, and a successful unsafe request invalidates the cached response for its URL.
+// Cached responses are keyed by the authorization of the request, so responses are never
+// shared between bearers.
+func cacheMiddleware(cache ResponseCache) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			key := cacheKey(req)
+
+			if req.Method != http.MethodGet {
+				resp, err := next.RoundTrip(req)
+				if err == nil && resp.StatusCode < 400 && req.Method != http.MethodHead && req.Method != http.MethodOptions {
+					cache.Delete(key)
+				}
+				return resp, err
+			}
+
+			reqCC := parseCacheControl(req.Header)
+			if reqCC.has("no-store") {
+				return next.RoundTrip(req)
+			}
+
+			cached, ok := cache.Get(key)
+			if ok && !cached.matches(req) {
+				ok = false
+			}
+
+			if ok && !reqCC.has("no-cache") && cached.fresh() {
+				common.Log.Tracef("serving fresh cached response to HTTP GET request: %s", req.URL.String())
+				return cached.response(req), nil
+			}
+
+			outbound := req
+			if ok && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+				etag := cached.Header.Get("ETag")
+				lastModified := cached.Header.Get("Last-Modified")
+				if etag != "" || lastModified != "" {
+					outbound = req.Clone(req.Context())
+					if etag != "" {
+						outbound.Header.Set("If-None-Match", etag)
+					}
+					if lastModified != "" {
+						outbound.Header.Set("If-Modified-Since", lastModified)
+					}
+				}
+			}
+
+			resp, err := next.RoundTrip(outbound)
+			if err != nil {
+				return resp, err
+			}
+
+			if resp.StatusCode == http.StatusNotModified && outbound != req {
+				io.Copy(ioutil.Discard, resp.Body)
+				resp.Body.Close()
+
+				revalidated := &CachedResponse{
+					Status:   cached.Status,
+					Header:   cached.Header.Clone(),
+					Body:     cached.Body,
+					Vary:     cached.Vary,
+					StoredAt: time.Now(),
+				}
+				for name, values := range resp.Header {
+					if name != "Content-Length" {
+						revalidated.Header[name] = values
+					}
+				}
+				cache.Set(key, revalidated)
+
+				common.Log.Tracef("revalidated cached response to HTTP GET request: %s", req.URL.String())
+				return revalidated.response(req), nil
+			}
+
+			if cacheable(reqCC, resp) {
+				resp.Body = &cachingBody{
+					ReadCloser: resp.Body,
+					cache:      cache,
+					key:        key,
+					req:        req,
+					resp:       resp,
+				}
+			} else if ok {
+				cache.Delete(key)
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// cacheKey returns the key of the response to a GET request having the url and authorization
+// of the given request
+func cacheKey(req *http.Request) string {
+	key := fmt.Sprintf("GET %s", req.URL.String())
+	if authorization := req.Header.Get("Authorization"); authorization != "" {
+		digest := sha256.Sum256([]byte(authorization))
+		key = fmt.Sprintf("%s %s", key, hex.EncodeToString(digest[:]))
+	}
+	return key
+}
+
+// cacheable returns true if the response may be stored; only 200 responses having a validator
+// or an explicit expiration are stored
+func cacheable(reqCC cacheControl, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	cc := parseCacheControl(resp.Header)
+	if cc.has("no-store") || reqCC.has("no-store") || resp.Header.Get("Vary") == "*" {
+		return false
+	}
+
+	return cc.has("max-age") || cc.has("no-cache") || resp.Header.Get("Expires") != "" ||
+		resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+// matches returns true if the request headers named by the Vary header of the cached response
+// match those of the original request
+func (c *CachedResponse) matches(req *http.Request) bool {
+	for name, val := range c.Vary {
+		if req.Header.Get(name) != val {
+			return false
+		}
+	}
+	return true
+}
+
+// fresh returns true if the cached response may be served without revalidation
+func (c *CachedResponse) fresh() bool {
+	cc := parseCacheControl(c.Header)
+	if cc.has("no-cache") {
+		return false
+	}
+
+	lifetime := time.Duration(-1)
+	if maxAge, err := strconv.ParseInt(cc["max-age"], 10, 64); err == nil {
+		lifetime = time.Duration(maxAge) * time.Second
+	} else if expires, err := http.ParseTime(c.Header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(c.Header.Get("Date"))
+		if err != nil {
+			date = c.StoredAt
+		}
+		lifetime = expires.Sub(date)
+	}
+
+	return lifetime > c.age()
+}
+
+// age returns the age of the cached response, including its age when it was stored
+func (c *CachedResponse) age() time.Duration {
+	age := time.Since(c.StoredAt)
+	if seconds, err := strconv.ParseInt(c.Header.Get("Age"), 10, 64); err == nil && seconds > 0 {
+		age += time.Duration(seconds) * time.Second
+	}
+	return age
+}
+
+// response returns an *http.Response for the cached response to the given request
+func (c *CachedResponse) response(req *http.Request) *http.Response {
+	header := c.Header.Clone()
+	header.Set("Age", strconv.FormatInt(int64(c.age()/time.Second), 10))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Status, http.StatusText(c.Status)),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// cachingBody buffers a response body as it is read, and stores the response once the body
+// has been read in its entirety; bodies larger than defaultMaxCacheableSize are not stored
+type cachingBody struct {
+	io.ReadCloser
+	cache    ResponseCache
+	key      string
+	req      *http.Request
+	resp     *http.Response
+	buf      bytes.Buffer
+	overflow bool
+	stored   bool
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if !b.overflow {
+		b.buf.Write(p[:n])
+		b.overflow = b.buf.Len() > defaultMaxCacheableSize
+	}
+
+	if err == io.EOF && !b.overflow && !b.stored {
+		b.stored = true
+		b.store()
+	}
+	return n, err
+}
+
+func (b *cachingBody) store() {
+	vary := map[string]string{}
+	for _, val := range b.resp.Header["Vary"] {
+		for _, name := range strings.Split(val, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				vary[http.CanonicalHeaderKey(name)] = b.req.Header.Get(name)
+			}
+		}
+	}
+
+	header := b.resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	b.cache.Set(b.key, &CachedResponse{
+		Status:   b.resp.StatusCode,
+		Header:   header,
+		Body:     append([]byte(nil), b.buf.Bytes()...),
+		Vary:     vary,
+		StoredAt: time.Now(),
+	})
+	common.Log.Tracef("cached %d-byte response to HTTP GET request: %s", b.buf.Len(), b.req.URL.String())
+}
+
+// LRUCache is an in-memory ResponseCache which evicts the least recently used response once
+// it holds its capacity
+type LRUCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	mutex    sync.Mutex
+}
+
+type lruEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewLRUCache returns an *LRUCache holding up to capacity responses; capacity is at least 1
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &LRUCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached response having the given key
+func (c *LRUCache) Get(key string) (*CachedResponse, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).resp, true
+}
+
+// Set caches the response using the given key, evicting the least recently used response if
+// the cache is full
+func (c *LRUCache) Set(key string, resp *CachedResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).resp = resp
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, resp: resp})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the cached response having the given key
+func (c *LRUCache) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of cached responses
+func (c *LRUCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
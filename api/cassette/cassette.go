@@ -0,0 +1,400 @@
+// Package cassette records the HTTP interactions of an api.Client to a cassette file and
+// replays them deterministically, for offline regression tests of the service clients.
+//
+//	rec, err := cassette.New("testdata/nchain_networks.json", cassette.ModeFromEnv(cassette.ModeReplay))
+//	...
+//	defer rec.Stop()
+//
+//	svc, err := nchain.NewService(api.WithMiddleware(rec.Middleware()))
+//
+// Sensitive headers, query parameters and JSON body fields are scrubbed before interactions
+// are written, and scrubbed values are ignored when matching requests during replay. When an
+// existing cassette is re-recorded, differences in the status and the shape of each response
+// are reported by Drift.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+const scrubbedValue = "[SCRUBBED]"
+
+// defaultScrubbedFields are the header, query parameter and JSON body field names which are
+// always scrubbed from recorded interactions
+var defaultScrubbedFields = []string{
+	"access_token",
+	"authorization",
+	"cookie",
+	"password",
+	"refresh_token",
+	"secret",
+	"set-cookie",
+	"token",
+	"x-api-key",
+}
+
+// Mode determines whether a Recorder replays or records interactions
+type Mode int
+
+const (
+	// ModeReplay replays interactions from the cassette; requests which match no recorded
+	// interaction fail without being sent
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests and records the interactions, replacing the cassette on Stop
+	ModeRecord
+
+	// ModeReplayOrRecord replays the cassette if it exists and records it otherwise
+	ModeReplayOrRecord
+)
+
+// ModeFromEnv resolves the mode from the CASSETTE_MODE environment variable, which may be one
+// of replay, record or auto; the given mode is returned if the variable is not set
+func ModeFromEnv(def Mode) Mode {
+	switch strings.ToLower(os.Getenv("CASSETTE_MODE")) {
+	case "replay":
+		return ModeReplay
+	case "record":
+		return ModeRecord
+	case "auto":
+		return ModeReplayOrRecord
+	}
+	return def
+}
+
+// Cassette is the set of recorded interactions persisted to a cassette file
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithScrubbedFields scrubs the named headers, query parameters and JSON body fields in
+// addition to the defaults; names are case-insensitive
+func WithScrubbedFields(fields ...string) Option {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.scrubbed[strings.ToLower(field)] = true
+		}
+	}
+}
+
+// WithMatcher replaces the matcher used to select the recorded interaction for a request
+// during replay; the default matcher is MatchAll(MatchMethod, MatchURL, MatchBody)
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// Recorder records or replays the interactions of the clients configured with its middleware
+type Recorder struct {
+	path     string
+	scrubbed map[string]bool
+	matcher  Matcher
+
+	mutex     sync.Mutex
+	cassette  *Cassette
+	previous  *Cassette
+	replayed  []bool
+	recording bool
+}
+
+// New initializes a *Recorder for the cassette file at the given path; in ModeReplay the
+// cassette must exist
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		scrubbed: map[string]bool{},
+		matcher:  MatchAll(MatchMethod, MatchURL, MatchBody),
+		cassette: &Cassette{Interactions: make([]*Interaction, 0)},
+	}
+
+	for _, field := range defaultScrubbedFields {
+		r.scrubbed[field] = true
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	existing, err := Load(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	switch mode {
+	case ModeReplay:
+		if existing == nil {
+			return nil, fmt.Errorf("cassette: %s does not exist; record it with CASSETTE_MODE=record", path)
+		}
+		r.cassette = existing
+	case ModeRecord:
+		r.previous = existing
+		r.recording = true
+	case ModeReplayOrRecord:
+		if existing != nil {
+			r.cassette = existing
+		} else {
+			r.recording = true
+		}
+	default:
+		return nil, fmt.Errorf("cassette: invalid mode: %d", mode)
+	}
+
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Load reads the cassette file at the given path
+func Load(path string) (*Cassette, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{}
+	err = json.Unmarshal(raw, cassette)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to parse %s; %s", path, err.Error())
+	}
+	return cassette, nil
+}
+
+// Recording returns true if the recorder sends requests and records their interactions
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Middleware returns api.Middleware which records or replays each request
+func (r *Recorder) Middleware() api.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return api.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if r.recording {
+				return r.record(next, req)
+			}
+			return r.replay(req)
+		})
+	}
+}
+
+// Stop writes the recorded interactions to the cassette file when recording; it is a no-op
+// when replaying
+func (r *Recorder) Stop() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	raw, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette: failed to marshal %s; %s", r.path, err.Error())
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0755)
+	if err != nil {
+		return fmt.Errorf("cassette: failed to write %s; %s", r.path, err.Error())
+	}
+
+	err = ioutil.WriteFile(r.path, append(raw, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("cassette: failed to write %s; %s", r.path, err.Error())
+	}
+
+	return nil
+}
+
+// Unplayed returns the recorded interactions which have not been replayed; tests may assert
+// it is empty to verify a cassette is fully exercised
+func (r *Recorder) Unplayed() []*Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	unplayed := make([]*Interaction, 0)
+	if r.recording {
+		return unplayed
+	}
+
+	for i, interaction := range r.cassette.Interactions {
+		if !r.replayed[i] {
+			unplayed = append(unplayed, interaction)
+		}
+	}
+	return unplayed
+}
+
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	req, reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: &Request{
+			Method: req.Method,
+			URL:    r.scrubURL(req.URL),
+			Header: r.scrubHeader(req.Header),
+			Body:   r.scrubBody(reqBody),
+		},
+		Response: &Response{
+			Status: resp.StatusCode,
+			Header: r.scrubHeader(resp.Header),
+			Body:   r.scrubBody(respBody),
+		},
+	}
+
+	r.mutex.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mutex.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the response of the first recorded interaction, not yet replayed, which
+// matches the request
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	req, body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &Request{
+		Method: req.Method,
+		URL:    r.scrubURL(req.URL),
+		Header: r.scrubHeader(req.Header),
+		Body:   r.scrubBody(body),
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !r.matcher(candidate, interaction.Request) {
+			continue
+		}
+
+		r.replayed[i] = true
+		return interaction.Response.httpResponse(req), nil
+	}
+
+	return nil, fmt.Errorf("cassette: no interaction recorded in %s matches %s %s", r.path, candidate.Method, candidate.URL)
+}
+
+func (resp *Response) httpResponse(req *http.Request) *http.Response {
+	header := http.Header{}
+	for name, vals := range resp.Header {
+		header[name] = append([]string(nil), vals...)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+		StatusCode:    resp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads the body of the request; unless the body can be obtained again using
+// GetBody, a clone of the request with a replacement body is returned so it can be sent
+func readRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	body := req.Body
+	if req.GetBody != nil {
+		var err error
+		body, err = req.GetBody()
+		if err != nil {
+			return nil, nil, fmt.Errorf("cassette: failed to read request body; %s", err.Error())
+		}
+	}
+
+	raw, err := ioutil.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cassette: failed to read request body; %s", err.Error())
+	}
+
+	if req.GetBody == nil {
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	}
+
+	return req, raw, nil
+}
+
+// readResponseBody reads the body of the response, decoding it using the content decoders of
+// the api package so that the recorded body is legible, and replaces it so it can be read by
+// the client
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Header.Get("Content-Encoding") != "" {
+		reader, err := api.ResponseReader(resp)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: failed to decode response body; %s", err.Error())
+		}
+		resp.Body = reader
+
+		resp.Header = resp.Header.Clone()
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to read response body; %s", err.Error())
+	}
+
+	resp.ContentLength = int64(len(body))
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
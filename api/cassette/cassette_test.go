@@ -0,0 +1,198 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/provideplatform/provide-go/api"
+)
+
+type network struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func networksServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+}
+
+func listNetworks(t *testing.T, baseURL string, rec *Recorder) ([]*network, error) {
+	config := &api.Config{}
+	err := config.Apply(
+		api.WithURL(baseURL),
+		api.WithToken("s3cr3t"),
+		api.WithMiddleware(rec.Middleware()),
+	)
+	if err != nil {
+		t.Fatalf("failed to configure client; %s", err.Error())
+	}
+
+	client := config.Client()
+	networks := make([]*network, 0)
+	_, err = client.GetInto(context.Background(), "networks", map[string]interface{}{"public": "true"}, &networks)
+	return networks, err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "networks.json")
+
+	srv := networksServer(`[{"id":"1","name":"mainnet"}]`)
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to initialize recorder; %s", err.Error())
+	}
+
+	_, err = listNetworks(t, srv.URL+"/api/v1", rec)
+	srv.Close()
+	if err != nil {
+		t.Fatalf("failed to record request; %s", err.Error())
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("failed to write cassette; %s", err.Error())
+	}
+
+	raw, _ := ioutil.ReadFile(path)
+	if strings.Contains(string(raw), "s3cr3t") {
+		t.Error("expected bearer token to be scrubbed from the cassette")
+	}
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load cassette; %s", err.Error())
+	}
+
+	networks, err := listNetworks(t, "http://replay.example.com/api/v1", rec)
+	if err != nil {
+		t.Fatalf("failed to replay request; %s", err.Error())
+	}
+	if len(networks) != 1 || networks[0].Name != "mainnet" {
+		t.Errorf("expected replayed networks; got %+v", networks)
+	}
+	if len(rec.Unplayed()) != 0 {
+		t.Error("expected all interactions to be replayed")
+	}
+
+	_, err = listNetworks(t, "http://replay.example.com/api/v1", rec)
+	if err == nil || !strings.Contains(err.Error(), "no interaction recorded") {
+		t.Errorf("expected error once the interaction has been replayed; got %v", err)
+	}
+}
+
+func TestDriftIsReportedWhenRerecording(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "networks.json")
+
+	for i, body := range []string{
+		`[{"id":"1","name":"mainnet","chain_id":1}]`,
+		`[{"id":"1","name":"mainnet","chain_id":"0x1","enabled":true}]`,
+	} {
+		srv := networksServer(body)
+		rec, err := New(path, ModeRecord)
+		if err != nil {
+			t.Fatalf("failed to initialize recorder; %s", err.Error())
+		}
+
+		_, err = listNetworks(t, srv.URL+"/api/v1", rec)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("failed to record request; %s", err.Error())
+		}
+
+		drift := rec.Drift()
+		if i == 0 && drift != nil {
+			t.Errorf("expected no drift for a new cassette; got %v", drift)
+		}
+		if i == 1 {
+			if len(drift) != 2 {
+				t.Fatalf("expected 2 drifts; got %v", drift)
+			}
+			if drift[0].Message != "$[].chain_id changed from number to string" || drift[1].Message != "$[].enabled was added" {
+				t.Errorf("unexpected drift; got %s, %s", drift[0], drift[1])
+			}
+		}
+
+		if err := rec.Stop(); err != nil {
+			t.Fatalf("failed to write cassette; %s", err.Error())
+		}
+	}
+}
+
+func TestRecordAndReplayBrotliEncodedResponse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "networks.json")
+
+	var encoded bytes.Buffer
+	writer := brotli.NewWriter(&encoded)
+	writer.Write([]byte(`[{"id":"1","name":"mainnet"}]`))
+	writer.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "br")
+		w.Write(encoded.Bytes())
+	}))
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to initialize recorder; %s", err.Error())
+	}
+
+	networks, err := listNetworks(t, srv.URL+"/api/v1", rec)
+	srv.Close()
+	if err != nil {
+		t.Fatalf("failed to record request; %s", err.Error())
+	}
+	if len(networks) != 1 || networks[0].Name != "mainnet" {
+		t.Errorf("expected recorded networks; got %+v", networks)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("failed to write cassette; %s", err.Error())
+	}
+
+	cassette, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load cassette; %s", err.Error())
+	}
+	resp := cassette.Interactions[0].Response
+	if resp.Header.Get("Content-Encoding") != "" || resp.Header.Get("Content-Length") != "" {
+		t.Errorf("expected content encoding headers to be removed; got %v", resp.Header)
+	}
+	if !strings.Contains(resp.Body, "mainnet") {
+		t.Errorf("expected decoded body to be recorded; got %q", resp.Body)
+	}
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load cassette; %s", err.Error())
+	}
+	networks, err = listNetworks(t, "http://replay.example.com/api/v1", rec)
+	if err != nil {
+		t.Fatalf("failed to replay request; %s", err.Error())
+	}
+	if len(networks) != 1 || networks[0].Name != "mainnet" {
+		t.Errorf("expected replayed networks; got %+v", networks)
+	}
+}
@@ -0,0 +1,147 @@
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Drift describes a difference between a re-recorded interaction and the interaction
+// previously recorded for the same request
+type Drift struct {
+	Method  string
+	URL     string
+	Message string
+}
+
+// String returns a description of the drift
+func (d *Drift) String() string {
+	return fmt.Sprintf("%s %s: %s", d.Method, d.URL, d.Message)
+}
+
+// Drift compares the interactions recorded so far with those previously recorded in the
+// cassette, and returns the requests which are new or no longer sent, and the responses whose
+// status or shape, i.e. the fields and types of a JSON body, has changed; values are not
+// compared. Drift returns nil unless an existing cassette is being re-recorded.
+func (r *Recorder) Drift() []*Drift {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.previous == nil {
+		return nil
+	}
+
+	drift := make([]*Drift, 0)
+	matched := make([]bool, len(r.previous.Interactions))
+
+	for _, interaction := range r.cassette.Interactions {
+		req := interaction.Request
+
+		var previous *Interaction
+		for i, candidate := range r.previous.Interactions {
+			if !matched[i] && r.matcher(req, candidate.Request) {
+				matched[i] = true
+				previous = candidate
+				break
+			}
+		}
+
+		if previous == nil {
+			drift = append(drift, &Drift{Method: req.Method, URL: req.URL, Message: "request was not previously recorded"})
+			continue
+		}
+
+		if previous.Response.Status != interaction.Response.Status {
+			drift = append(drift, &Drift{
+				Method:  req.Method,
+				URL:     req.URL,
+				Message: fmt.Sprintf("status changed from %d to %d", previous.Response.Status, interaction.Response.Status),
+			})
+		}
+
+		for _, msg := range compareShape("$", bodyShape(previous.Response.Body), bodyShape(interaction.Response.Body)) {
+			drift = append(drift, &Drift{Method: req.Method, URL: req.URL, Message: msg})
+		}
+	}
+
+	for i, interaction := range r.previous.Interactions {
+		if !matched[i] {
+			drift = append(drift, &Drift{Method: interaction.Request.Method, URL: interaction.Request.URL, Message: "previously recorded request was not sent"})
+		}
+	}
+
+	return drift
+}
+
+func bodyShape(body string) interface{} {
+	val, ok := parseJSON([]byte(body))
+	if !ok {
+		return nil
+	}
+	return val
+}
+
+// compareShape returns a description of each field added, removed or having a different type
+// in the current value; the elements of arrays are compared against the first element
+func compareShape(path string, previous, current interface{}) []string {
+	if jsonType(previous) != jsonType(current) {
+		if previous == nil || current == nil {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s changed from %s to %s", path, jsonType(previous), jsonType(current))}
+	}
+
+	diffs := make([]string, 0)
+	switch prev := previous.(type) {
+	case map[string]interface{}:
+		cur := current.(map[string]interface{})
+		keys := make([]string, 0, len(prev)+len(cur))
+		for key := range prev {
+			keys = append(keys, key)
+		}
+		for key := range cur {
+			if _, ok := prev[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := fmt.Sprintf("%s.%s", path, key)
+			prevField, inPrev := prev[key]
+			curField, inCur := cur[key]
+			switch {
+			case !inPrev:
+				diffs = append(diffs, fmt.Sprintf("%s was added", fieldPath))
+			case !inCur:
+				diffs = append(diffs, fmt.Sprintf("%s was removed", fieldPath))
+			default:
+				diffs = append(diffs, compareShape(fieldPath, prevField, curField)...)
+			}
+		}
+	case []interface{}:
+		cur := current.([]interface{})
+		if len(prev) > 0 && len(cur) > 0 {
+			diffs = append(diffs, compareShape(fmt.Sprintf("%s[]", path), prev[0], cur[0])...)
+		}
+	}
+	return diffs
+}
+
+func jsonType(val interface{}) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "unknown"
+}
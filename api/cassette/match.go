@@ -0,0 +1,102 @@
+package cassette
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Matcher returns true if the given request matches the recorded request; both requests have
+// been scrubbed
+type Matcher func(req, recorded *Request) bool
+
+// MatchAll returns a Matcher which matches when each of the given matchers matches
+func MatchAll(matchers ...Matcher) Matcher {
+	return func(req, recorded *Request) bool {
+		for _, matcher := range matchers {
+			if !matcher(req, recorded) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchMethod matches requests having the same method
+func MatchMethod(req, recorded *Request) bool {
+	return strings.EqualFold(req.Method, recorded.Method)
+}
+
+// MatchURL matches requests having the same path and query parameters, in any order; the
+// scheme and host are ignored so cassettes can be replayed against any environment
+func MatchURL(req, recorded *Request) bool {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+
+	ru, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+
+	return strings.Trim(u.Path, "/") == strings.Trim(ru.Path, "/") && reflect.DeepEqual(u.Query(), ru.Query())
+}
+
+// MatchPath matches requests having the same path, ignoring query parameters
+func MatchPath(req, recorded *Request) bool {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+
+	ru, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+
+	return strings.Trim(u.Path, "/") == strings.Trim(ru.Path, "/")
+}
+
+// MatchBody matches requests having equivalent bodies; JSON bodies are compared without
+// regard to formatting or the order of object fields
+func MatchBody(req, recorded *Request) bool {
+	val, ok := parseJSON([]byte(req.Body))
+	if !ok {
+		return req.Body == recorded.Body
+	}
+
+	recordedVal, ok := parseJSON([]byte(recorded.Body))
+	if !ok {
+		return false
+	}
+
+	return reflect.DeepEqual(val, recordedVal)
+}
+
+// MatchIgnoringFields returns a Matcher which matches requests having equivalent JSON bodies
+// once the named top-level fields, such as nonces or timestamps, are removed from both
+func MatchIgnoringFields(fields ...string) Matcher {
+	return func(req, recorded *Request) bool {
+		val, ok := parseJSON([]byte(req.Body))
+		if !ok {
+			return req.Body == recorded.Body
+		}
+
+		recordedVal, ok := parseJSON([]byte(recorded.Body))
+		if !ok {
+			return false
+		}
+
+		for _, field := range fields {
+			if obj, ok := val.(map[string]interface{}); ok {
+				delete(obj, field)
+			}
+			if obj, ok := recordedVal.(map[string]interface{}); ok {
+				delete(obj, field)
+			}
+		}
+
+		return reflect.DeepEqual(val, recordedVal)
+	}
+}
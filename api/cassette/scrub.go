@@ -0,0 +1,91 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (r *Recorder) scrubURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	query := u.Query()
+	for key := range query {
+		if r.scrubbed[strings.ToLower(key)] {
+			query.Set(key, scrubbedValue)
+		}
+	}
+
+	scrubbed := *u
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
+
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	scrubbed := http.Header{}
+	for name, vals := range header {
+		if r.scrubbed[strings.ToLower(name)] {
+			scrubbed[name] = []string{scrubbedValue}
+		} else {
+			scrubbed[name] = append([]string(nil), vals...)
+		}
+	}
+	return scrubbed
+}
+
+// scrubBody replaces the values of scrubbed fields, at any depth, of a JSON body; other
+// bodies are returned unmodified
+func (r *Recorder) scrubBody(body []byte) string {
+	val, ok := parseJSON(body)
+	if !ok {
+		return string(body)
+	}
+
+	scrubbed, err := json.Marshal(r.scrubValue(val))
+	if err != nil {
+		return string(body)
+	}
+	return string(scrubbed)
+}
+
+func (r *Recorder) scrubValue(val interface{}) interface{} {
+	switch val := val.(type) {
+	case map[string]interface{}:
+		for key, field := range val {
+			if r.scrubbed[strings.ToLower(key)] {
+				val[key] = scrubbedValue
+			} else {
+				val[key] = r.scrubValue(field)
+			}
+		}
+	case []interface{}:
+		for i, elem := range val {
+			val[i] = r.scrubValue(elem)
+		}
+	}
+	return val
+}
+
+// parseJSON parses the given body, preserving the precision of numbers
+func parseJSON(body []byte) (interface{}, bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+
+	var val interface{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&val); err != nil {
+		return nil, false
+	}
+	return val, true
+}
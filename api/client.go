@@ -2,13 +2,14 @@ package api
 
 import (
 	"bytes"
-	"compress/gzip"
+	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -24,6 +25,10 @@ import (
 const defaultContentType = "application/json"
 const defaultRequestTimeout = time.Second * 10
 
+// DefaultMaxResponseSize is the maximum decoded size, in bytes, of a response body read into
+// memory, unless overridden by the MaxResponseSize of a client
+const DefaultMaxResponseSize = 64 << 20
+
 var customRequestTimeout *time.Duration
 
 // Client is a generic base class for calling a REST API; when a token is configured on an
@@ -31,7 +36,8 @@ var customRequestTimeout *time.Duration
 // password are configured on an Client instance, they will be used for HTTP basic authorization
 // but will be passed as the Authorization header instead of as part of the URL itself. When a token
 // is configured on an Client instance, the username and password supplied for basic auth are
-// currently discarded.
+// currently discarded. Responses having a 4xx or 5xx status are returned along with an
+// *APIError describing the failure.
 type Client struct {
 	Host   string
 	Path   string
@@ -41,8 +47,60 @@ type Client struct {
 	Headers map[string][]string
 	Token   *string
 
+	// TokenSource, when set, provides the bearer token for each request in place of Token;
+	// a request rejected with a 401 status is retried once using a freshly-resolved token
+	TokenSource TokenSource
+
 	Username *string
 	Password *string
+
+	// UserAgent, when set, is sent as the User-Agent header of each request
+	UserAgent *string
+
+	// Timeout, when positive, overrides the REQUEST_TIMEOUT of the environment
+	Timeout time.Duration
+
+	// TLSClientConfig, when set, is used by the pooled transport in place of the default
+	// TLS configuration; see WithCABundle, WithClientCertificate and WithPinnedPublicKeys
+	TLSClientConfig *tls.Config
+
+	// Middleware wraps the round tripper used to send each request; it is applied within any
+	// middleware registered globally using Use
+	Middleware []Middleware
+
+	// HTTPClient, when set, is used to send all requests instead of an *http.Client backed
+	// by a pooled transport; TransportConfig, TLSClientConfig, Timeout and REQUEST_TIMEOUT
+	// are ignored in this case
+	HTTPClient *http.Client
+
+	// RetryPolicy, when set, overrides the retry policy configured in the environment
+	RetryPolicy *RetryPolicy
+
+	// TransportConfig, when set, overrides the default configuration of the pooled transport
+	TransportConfig *TransportConfig
+
+	// MaxResponseSize, when positive, overrides DefaultMaxResponseSize; when negative, response
+	// bodies are read without limit. Streams are never limited.
+	MaxResponseSize int64
+
+	// RateLimiter, when set, limits the rate of requests sent to each host
+	RateLimiter *RateLimiter
+
+	// CircuitBreaker, when set, stops sending requests to a host which is failing
+	CircuitBreaker *CircuitBreaker
+
+	// Cache, when set, caches the responses to GET requests in accordance with their
+	// Cache-Control, ETag and Last-Modified headers
+	Cache ResponseCache
+
+	// Service is the name of the API, used to identify it in traces and metrics
+	Service string
+
+	// Tracer, when set, overrides the tracer set using SetTracer
+	Tracer Tracer
+
+	// Metrics, when set, overrides the metrics recorder set using SetMetrics
+	Metrics Metrics
 }
 
 func requestTimeout() time.Duration {
@@ -74,91 +132,152 @@ func (c *Client) parseResponse(resp *http.Response) (status int, response interf
 		return 0, nil, errors.New("nil response")
 	}
 
-	if resp.Body != nil {
-		defer resp.Body.Close()
+	body, err := c.readResponse(resp)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+
+	if len(body) > 0 {
+		mediaType := strings.ToLower(strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0]))
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			err = json.Unmarshal(body, &response)
+			if err != nil {
+				err = fmt.Errorf("failed to unmarshal %v-byte HTTP %s response from %s; %s", len(body), resp.Request.Method, resp.Request.URL.String(), err.Error())
+				return resp.StatusCode, nil, err
+			}
+		case strings.HasPrefix(mediaType, "text/"):
+			response = string(body)
+		default:
+			response = body
+		}
 	}
+
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, response, NewAPIError(resp, body)
+	}
+
+	return resp.StatusCode, response, nil
+}
+
+// readResponse reads the entire body of the given response, decoding it in accordance with
+// its content encoding; the body is closed upon return
+func (c *Client) readResponse(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+
+	reader, err := ResponseReader(resp)
 	if err != nil {
-		common.Log.Warningf("failed to invoke HTTP %s request: %s; %s", resp.Request.Method, resp.Request.URL.String(), err.Error())
-		return 0, nil, err
+		return nil, err
 	}
+	defer reader.Close()
 
-	var reader io.ReadCloser
-	switch resp.Header.Get("Content-Encoding") {
-	case "gzip":
-		reader, err = gzip.NewReader(resp.Body)
-	default:
-		reader = resp.Body
+	limit := c.maxResponseSize()
+	var limited io.Reader = reader
+	if limit > 0 {
+		limited = io.LimitReader(reader, limit+1)
 	}
 
-	buf := new(bytes.Buffer)
-	if reader != nil {
-		defer reader.Close()
+	body, err := ioutil.ReadAll(limited)
+	if err != nil {
+		common.Log.Warningf("failed to read HTTP response stream; %s", err.Error())
+		return nil, err
+	}
 
-		for {
-			buffer := make([]byte, 256)
-			n, err := reader.Read(buffer)
-			if n > 0 {
-				common.Log.Tracef("read %d bytes from HTTP response stream", n)
-				i, err := buf.Write(buffer[0:n])
-				if err != nil {
-					common.Log.Warningf("failed to write HTTP response to internal client buffer; %s", err.Error())
-					return resp.StatusCode, nil, err
-				} else {
-					common.Log.Tracef("wrote %d bytes from HTTP response to internal client buffer", i)
-				}
-			} else if err != nil {
-				if err != io.EOF {
-					common.Log.Warningf("failed to read HTTP response stream; %s", err.Error())
-					return resp.StatusCode, nil, err
-				}
-				break
-			}
-		}
+	if limit > 0 && int64(len(body)) > limit {
+		common.Log.Warningf("HTTP %s response from %s exceeds the maximum response size of %d bytes", resp.Request.Method, resp.Request.URL.String(), limit)
+		return nil, fmt.Errorf("%w; read more than %d bytes", ErrResponseTooLarge, limit)
 	}
 
-	if buf.Len() > 0 {
-		contentTypeParts := strings.Split(resp.Header.Get("Content-Type"), ";")
-		switch strings.ToLower(contentTypeParts[0]) {
-		case "application/json":
-			err = json.Unmarshal(buf.Bytes(), &response)
-			if err != nil {
-				err = fmt.Errorf("failed to unmarshal %v-byte HTTP %s response from %s; %s", len(buf.Bytes()), resp.Request.Method, resp.Request.URL.String(), err.Error())
-				return resp.StatusCode, nil, err
-			}
-		default:
-			// no-op
+	common.Log.Tracef("read %d bytes from HTTP response stream", len(body))
+	return body, nil
+}
+
+// maxResponseSize returns the maximum decoded size of a response body read into memory, or a
+// non-positive value when unlimited
+func (c *Client) maxResponseSize() int64 {
+	if c.MaxResponseSize != 0 {
+		return c.MaxResponseSize
+	}
+	return DefaultMaxResponseSize
+}
+
+// requestHeaders returns the headers, including authorization, sent with each request
+func (c *Client) requestHeaders(ctx context.Context, method, urlString string) (map[string][]string, error) {
+	headers := map[string][]string{
+		"Accept-Encoding": {acceptEncoding()},
+		"Accept-Language": {"en-us"},
+		"Accept":          {"application/json"},
+	}
+
+	authorization, err := c.authorization(ctx)
+	if err != nil {
+		common.Log.Warningf("failed to authorize HTTP %s request: %s; %s", method, urlString, err.Error())
+		return nil, err
+	} else if authorization != nil {
+		headers["Authorization"] = []string{*authorization}
+	}
+
+	if c.UserAgent != nil {
+		headers["User-Agent"] = []string{*c.UserAgent}
+	}
+
+	if c.Cookie != nil {
+		headers["Cookie"] = []string{*c.Cookie}
+	}
+
+	if c.Headers != nil {
+		for name, val := range c.Headers {
+			headers[name] = val
 		}
 	}
 
-	return resp.StatusCode, response, nil
+	return headers, nil
 }
 
 func (c *Client) sendRequest(
+	ctx context.Context,
 	method,
 	urlString,
 	contentType string,
 	params map[string]interface{},
 ) (resp *http.Response, err error) {
-	return c.sendRequestWithTLSClientConfig(method, urlString, contentType, params,
-		&tls.Config{
-			InsecureSkipVerify: false,
-		},
-	)
+	return c.send(ctx, method, urlString, contentType, params, &requestOptions{})
 }
 
-func (c *Client) sendRequestWithTLSClientConfig(
+// withTLSClientConfig returns a copy of the client which uses the given TLS configuration, or
+// the client itself if it is nil
+func (c *Client) withTLSClientConfig(tlsClientConfig *tls.Config) *Client {
+	if tlsClientConfig == nil {
+		return c
+	}
+	client := *c
+	client.TLSClientConfig = tlsClientConfig
+	return &client
+}
+
+// requestOptions alter how a request is sent
+type requestOptions struct {
+	// accept replaces the default Accept header
+	accept string
+
+	// streaming requests are not bound by the default request timeout, so their response
+	// bodies can be read for as long as the context allows
+	streaming bool
+}
+
+func (c *Client) send(
+	ctx context.Context,
 	method,
 	urlString,
 	contentType string,
 	params map[string]interface{},
-	tlsClientConfig *tls.Config,
+	opts *requestOptions,
 ) (resp *http.Response, err error) {
-	client := &http.Client{
-		Transport: &http.Transport{
-			DisableKeepAlives: true,
-			TLSClientConfig:   tlsClientConfig,
-		},
-		Timeout: requestTimeout(),
+	client := c.httpClient()
+	if opts.streaming {
+		client = c.streamingHTTPClient()
 	}
 
 	mthd := strings.ToUpper(method)
@@ -178,32 +297,19 @@ func (c *Client) sendRequestWithTLSClientConfig(
 		reqURL.RawQuery = q.Encode()
 	}
 
-	headers := map[string][]string{
-		"Accept-Encoding": {"gzip, deflate"},
-		"Accept-Language": {"en-us"},
-		"Accept":          {"application/json"},
-	}
-
-	if c.Token != nil {
-		headers["Authorization"] = []string{fmt.Sprintf("bearer %s", *c.Token)}
-	} else if c.Username != nil && c.Password != nil {
-		headers["Authorization"] = []string{buildBasicAuthorizationHeader(*c.Username, *c.Password)}
+	headers, err := c.requestHeaders(ctx, method, urlString)
+	if err != nil {
+		return nil, err
 	}
 
-	if c.Cookie != nil {
-		headers["Cookie"] = []string{*c.Cookie}
+	if opts.accept != "" {
+		headers["Accept"] = []string{opts.accept}
 	}
 
-	if c.Headers != nil {
-		for name, val := range c.Headers {
-			headers[name] = val
-		}
-	}
+	var payload []byte
+	hasBody := mthd == "POST" || mthd == "PUT" || mthd == "PATCH"
 
-	var req *http.Request
-
-	if mthd == "POST" || mthd == "PUT" || mthd == "PATCH" {
-		var payload []byte
+	if hasBody {
 		switch contentType {
 		case "application/json":
 			payload, err = json.Marshal(params)
@@ -253,125 +359,262 @@ func (c *Client) sendRequestWithTLSClientConfig(
 			common.Log.Warningf("attempted HTTP %s request with unsupported content type: %s; unable to marshal request body", mthd, contentType)
 		}
 
-		req, _ = http.NewRequest(method, urlString, bytes.NewReader(payload))
 		headers["Content-Type"] = []string{contentType}
-	} else {
-		req = &http.Request{
-			URL:    reqURL,
-			Method: mthd,
+	}
+
+	ctx, telemetry := c.instrument(ctx, mthd, reqURL, headers)
+	defer func() {
+		telemetry.end(resp, err)
+	}()
+
+	policy := c.retryPolicy()
+	maxAttempts := 1
+	if isIdempotentMethod(mthd) || headers[idempotencyKeyHeader] != nil {
+		maxAttempts = policy.MaxAttempts
+	} else if policy.IdempotencyKeys {
+		idempotencyKey, err := generateIdempotencyKey()
+		if err != nil {
+			common.Log.Warningf("failed to generate idempotency key for HTTP %s request: %s; %s", mthd, urlString, err.Error())
+			return nil, err
 		}
+		headers[idempotencyKeyHeader] = []string{idempotencyKey}
+		maxAttempts = policy.MaxAttempts
 	}
 
-	req.Header = headers
-	return client.Do(req)
+	reauthorized := false
+	for attempt := 1; ; attempt++ {
+		var req *http.Request
+		if hasBody {
+			req, err = http.NewRequestWithContext(ctx, mthd, urlString, bytes.NewReader(payload))
+		} else {
+			req, err = http.NewRequestWithContext(ctx, mthd, reqURL.String(), nil)
+		}
+		if err != nil {
+			common.Log.Warningf("failed to initialize HTTP %s request: %s; %s", method, urlString, err.Error())
+			return nil, err
+		}
+
+		req.Header = headers
+		resp, err = client.Do(req)
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.TokenSource != nil && !reauthorized {
+			reauthorized = true
+			authorization, err := c.reauthorize(ctx, headers["Authorization"][0])
+			if err != nil {
+				common.Log.Warningf("failed to reauthorize HTTP %s request: %s after 401 response; %s", mthd, urlString, err.Error())
+			} else {
+				common.Log.Debugf("HTTP %s request: %s returned 401; retrying with fresh bearer token", mthd, urlString)
+				io.Copy(ioutil.Discard, resp.Body)
+				resp.Body.Close()
+				headers["Authorization"] = []string{*authorization}
+				attempt--
+				continue
+			}
+		}
+
+		if attempt >= maxAttempts || !policy.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay, ok := policy.backoff(attempt, resp)
+		if !ok {
+			common.Log.Debugf("HTTP %s request: %s returned retryable status %d; not retrying as Retry-After of %v exceeds max backoff", mthd, urlString, resp.StatusCode, delay)
+			return resp, err
+		}
+		if resp != nil {
+			common.Log.Debugf("HTTP %s request: %s returned retryable status %d; retrying in %v (attempt %d of %d)", mthd, urlString, resp.StatusCode, delay, attempt+1, maxAttempts)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			common.Log.Debugf("HTTP %s request: %s failed; retrying in %v (attempt %d of %d); %s", mthd, urlString, delay, attempt+1, maxAttempts, err.Error())
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		telemetry.retry()
+	}
 }
 
 // Get constructs and synchronously sends an API GET request
 func (c *Client) Get(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+	return c.GetWithContext(context.Background(), uri, params)
+}
+
+// GetWithContext constructs and synchronously sends an API GET request using the given context
+func (c *Client) GetWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("GET", url, defaultContentType, params)
+	resp, err := c.sendRequest(ctx, "GET", url, defaultContentType, params)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
 // Head constructs and synchronously sends an API HEAD request; returns the headers
 func (c *Client) Head(uri string, params map[string]interface{}) (status int, response map[string][]string, err error) {
+	return c.HeadWithContext(context.Background(), uri, params)
+}
+
+// HeadWithContext constructs and synchronously sends an API HEAD request using the given context; returns the headers
+func (c *Client) HeadWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response map[string][]string, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("HEAD", url, defaultContentType, params)
+	resp, err := c.sendRequest(ctx, "HEAD", url, defaultContentType, params)
 	if err != nil {
-		return resp.StatusCode, nil, err
+		return 0, nil, err
+	}
+	if resp.Body != nil {
+		resp.Body.Close()
 	}
+
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, resp.Header, NewAPIError(resp, nil)
+	}
+
 	return resp.StatusCode, resp.Header, nil
 }
 
-// GetWithTLSClientConfig constructs and synchronously sends an API GET request
+// GetWithTLSClientConfig constructs and synchronously sends an API GET request using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) GetWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("GET", url, defaultContentType, params, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).GetWithContext(context.Background(), uri, params)
 }
 
 // Patch constructs and synchronously sends an API PATCH request
 func (c *Client) Patch(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+	return c.PatchWithContext(context.Background(), uri, params)
+}
+
+// PatchWithContext constructs and synchronously sends an API PATCH request using the given context
+func (c *Client) PatchWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("PATCH", url, defaultContentType, params)
+	resp, err := c.sendRequest(ctx, "PATCH", url, defaultContentType, params)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
-// PatchWithTLSClientConfig constructs and synchronously sends an API PATCH request
+// PatchWithTLSClientConfig constructs and synchronously sends an API PATCH request using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) PatchWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("PATCH", url, defaultContentType, params, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).PatchWithContext(context.Background(), uri, params)
 }
 
 // Post constructs and synchronously sends an API POST request
 func (c *Client) Post(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+	return c.PostWithContext(context.Background(), uri, params)
+}
+
+// PostWithContext constructs and synchronously sends an API POST request using the given context
+func (c *Client) PostWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("POST", url, defaultContentType, params)
+	resp, err := c.sendRequest(ctx, "POST", url, defaultContentType, params)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
-// PostWithTLSClientConfig constructs and synchronously sends an API POST request
+// PostWithTLSClientConfig constructs and synchronously sends an API POST request using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) PostWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("POST", url, defaultContentType, params, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).PostWithContext(context.Background(), uri, params)
 }
 
 // PostWWWFormURLEncoded constructs and synchronously sends an API POST request using application/x-www-form-urlencoded as the content-type
 func (c *Client) PostWWWFormURLEncoded(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+	return c.PostWWWFormURLEncodedWithContext(context.Background(), uri, params)
+}
+
+// PostWWWFormURLEncodedWithContext constructs and synchronously sends an API POST request using application/x-www-form-urlencoded as the content-type and the given context
+func (c *Client) PostWWWFormURLEncodedWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("POST", url, "application/x-www-form-urlencoded", params)
+	resp, err := c.sendRequest(ctx, "POST", url, "application/x-www-form-urlencoded", params)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
-// PostWWWFormURLEncodedWithTLSClientConfig constructs and synchronously sends an API POST request using application/x-www-form-urlencoded as the content-type
+// PostWWWFormURLEncodedWithTLSClientConfig constructs and synchronously sends an API POST request using application/x-www-form-urlencoded as the content-type using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) PostWWWFormURLEncodedWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("POST", url, "application/x-www-form-urlencoded", params, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).PostWWWFormURLEncodedWithContext(context.Background(), uri, params)
 }
 
-// PostMultipartFormData constructs and synchronously sends an API POST request using multipart/form-data as the content-type
+// PostMultipartFormData constructs and synchronously sends an API POST request using multipart/form-data as the content-type;
+// the request body is buffered in memory, see PostMultipart to stream large parts
 func (c *Client) PostMultipartFormData(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+	return c.PostMultipartFormDataWithContext(context.Background(), uri, params)
+}
+
+// PostMultipartFormDataWithContext constructs and synchronously sends an API POST request using multipart/form-data as the content-type and the given context
+func (c *Client) PostMultipartFormDataWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("POST", url, "multipart/form-data", params)
+	resp, err := c.sendRequest(ctx, "POST", url, "multipart/form-data", params)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
-// PostMultipartFormDataWithTLSClientConfig constructs and synchronously sends an API POST request using multipart/form-data as the content-type
+// PostMultipartFormDataWithTLSClientConfig constructs and synchronously sends an API POST request using multipart/form-data as the content-type using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) PostMultipartFormDataWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("POST", url, "multipart/form-data", params, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).PostMultipartFormDataWithContext(context.Background(), uri, params)
 }
 
 // Put constructs and synchronously sends an API PUT request
 func (c *Client) Put(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+	return c.PutWithContext(context.Background(), uri, params)
+}
+
+// PutWithContext constructs and synchronously sends an API PUT request using the given context
+func (c *Client) PutWithContext(ctx context.Context, uri string, params map[string]interface{}) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("PUT", url, defaultContentType, params)
+	resp, err := c.sendRequest(ctx, "PUT", url, defaultContentType, params)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
-// PutWithTLSClientConfig constructs and synchronously sends an API PUT request
+// PutWithTLSClientConfig constructs and synchronously sends an API PUT request using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) PutWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("PUT", url, defaultContentType, params, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).PutWithContext(context.Background(), uri, params)
 }
 
 // Delete constructs and synchronously sends an API DELETE request
 func (c *Client) Delete(uri string) (status int, response interface{}, err error) {
+	return c.DeleteWithContext(context.Background(), uri)
+}
+
+// DeleteWithContext constructs and synchronously sends an API DELETE request using the given context
+func (c *Client) DeleteWithContext(ctx context.Context, uri string) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("DELETE", url, defaultContentType, nil)
+	resp, err := c.sendRequest(ctx, "DELETE", url, defaultContentType, nil)
+	if err != nil {
+		return 0, nil, err
+	}
 	return c.parseResponse(resp)
 }
 
-// DeleteWithTLSClientConfig constructs and synchronously sends an API DELETE request
+// DeleteWithTLSClientConfig constructs and synchronously sends an API DELETE request using the given TLS configuration
+//
+// Deprecated: set TLSClientConfig on the client instead, e.g. using WithTLSClientConfig
 func (c *Client) DeleteWithTLSClientConfig(uri string, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
-	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("DELETE", url, defaultContentType, nil, tlsClientConfig)
-	return c.parseResponse(resp)
+	return c.withTLSClientConfig(tlsClientConfig).DeleteWithContext(context.Background(), uri)
 }
 
 func (c *Client) buildURL(uri string) string {
@@ -0,0 +1,1055 @@
+package api
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+func testClient(t *testing.T, srv *httptest.Server) *Client {
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server url; %s", err.Error())
+	}
+
+	return &Client{
+		Host:   u.Host,
+		Path:   "api/v1",
+		Scheme: u.Scheme,
+	}
+}
+
+func testRetryPolicy(idempotencyKeys bool) *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = time.Millisecond * 5
+	policy.IdempotencyKeys = idempotencyKeys
+	return policy
+}
+
+func TestGetRetriesTransientStatus(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.RetryPolicy = testRetryPolicy(false)
+
+	status, resp, err := client.Get("status", nil)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if status != 200 || resp == nil {
+		t.Errorf("expected 200 status with response; got %d", status)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts; got %d", attempts)
+	}
+}
+
+func TestPostIsNotRetriedWithoutIdempotencyKey(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.RetryPolicy = testRetryPolicy(false)
+
+	status, _, _ := client.Post("transactions", map[string]interface{}{})
+	if status != http.StatusBadGateway {
+		t.Errorf("expected 502 status; got %d", status)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt; got %d", attempts)
+	}
+}
+
+func TestPostIsRetriedWithIdempotencyKey(t *testing.T) {
+	var attempts int32
+	keys := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys[r.Header.Get("Idempotency-Key")] = true
+		if atomic.AddInt32(&attempts, 1) < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.RetryPolicy = testRetryPolicy(true)
+
+	status, _, err := client.Post("transactions", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if status != http.StatusCreated {
+		t.Errorf("expected 201 status; got %d", status)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts; got %d", attempts)
+	}
+	if len(keys) != 1 || keys[""] {
+		t.Errorf("expected a single idempotency key to be reused across attempts; got %v", keys)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("3"); !ok || d != time.Second*3 {
+		t.Errorf("expected 3s delay; got %v", d)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected invalid Retry-After to be ignored")
+	}
+	if d, ok := parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)); !ok || d != 0 {
+		t.Errorf("expected zero delay for past Retry-After date; got %v", d)
+	}
+}
+
+func TestRetryAfterExceedingMaxBackoffIsNotRetried(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.RetryPolicy = testRetryPolicy(false)
+
+	started := time.Now()
+	status, _, _ := client.Get("status", nil)
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 status; got %d", status)
+	}
+	if attempts != 1 || time.Since(started) > time.Second {
+		t.Errorf("expected a single attempt without waiting; got %d attempts in %v", attempts, time.Since(started))
+	}
+}
+
+func TestConnectionResetIsRetried(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.RetryPolicy = testRetryPolicy(false)
+
+	status, _, err := client.Get("status", nil)
+	if err != nil || status != 200 {
+		t.Fatalf("expected the request to succeed after the connection was closed; got %d, %v", status, err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts; got %d", attempts)
+	}
+}
+
+func TestCertificateErrorsAreNotRetried(t *testing.T) {
+	for name, tlsClientConfig := range map[string]*tls.Config{
+		"unknown authority": nil,
+		"pin mismatch": {
+			InsecureSkipVerify:    true,
+			VerifyPeerCertificate: verifyPinnedPublicKeys([]string{"sha256/bm90IHRoZSBwaW4="}, nil),
+		},
+	} {
+		var connections int32
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+		srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&connections, 1)
+			}
+		}
+		srv.StartTLS()
+
+		client := testClient(t, srv)
+		client.TLSClientConfig = tlsClientConfig
+		client.RetryPolicy = testRetryPolicy(false)
+
+		_, _, err := client.Get("status", nil)
+		srv.Close()
+		if err == nil {
+			t.Errorf("expected %s to fail", name)
+		}
+		if connections != 1 {
+			t.Errorf("expected %s not to be retried; got %d connections", name, connections)
+		}
+	}
+}
+
+func TestClientsShareTransport(t *testing.T) {
+	a := &Client{Host: "ident.provide.services", Scheme: "https"}
+	b := &Client{Host: "vault.provide.services", Scheme: "https"}
+	if a.transport(nil) != b.transport(nil) {
+		t.Error("expected clients with equivalent configuration to share a pooled transport")
+	}
+
+	b.TransportConfig = DefaultTransportConfig()
+	b.TransportConfig.MaxIdleConnsPerHost = 64
+	if a.transport(nil) == b.transport(nil) {
+		t.Error("expected clients with distinct transport configuration to use distinct transports")
+	}
+}
+
+func TestTransportPoolIsBounded(t *testing.T) {
+	client := &Client{Host: "ident.provide.services", Scheme: "https"}
+	first := &tls.Config{}
+	transport := client.transport(first)
+
+	for i := 0; i < maxPooledTransports; i++ {
+		client.transport(&tls.Config{})
+	}
+
+	transportsMutex.Lock()
+	size := transportsLRU.Len()
+	transportsMutex.Unlock()
+	if size > maxPooledTransports {
+		t.Errorf("expected at most %d pooled transports; got %d", maxPooledTransports, size)
+	}
+	if client.transport(first) == transport {
+		t.Error("expected the least recently used transport to be evicted")
+	}
+}
+
+func TestAPIErrorIsReturnedForErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"message":"key not found"}]}`))
+	}))
+	defer srv.Close()
+
+	status, _, err := testClient(t, srv).Get("vaults/1/keys/2", nil)
+	if status != http.StatusNotFound {
+		t.Errorf("expected 404 status; got %d", status)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError; got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict) {
+		t.Errorf("expected error to match ErrNotFound only; got %s", err.Error())
+	}
+	if apiErr.Method != http.MethodGet || apiErr.RequestID == nil || *apiErr.RequestID != "abc123" {
+		t.Errorf("expected request method and id to be populated; got %s", apiErr.Error())
+	}
+	if len(apiErr.Errors) != 1 || *apiErr.Errors[0].Message != "key not found" {
+		t.Errorf("expected decoded errors array; got %s", apiErr.Error())
+	}
+}
+
+func TestDeflateResponsesAreDecoded(t *testing.T) {
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		if raw {
+			w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+		} else {
+			w = zlib.NewWriter(&buf)
+		}
+		w.Write([]byte(`{"id":"1"}`))
+		w.Close()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "deflate") {
+				t.Errorf("expected deflate to be accepted; got %s", r.Header.Get("Accept-Encoding"))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "deflate")
+			w.Write(buf.Bytes())
+		}))
+
+		_, resp, err := testClient(t, srv).Get("networks/1", nil)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("failed to decode deflate response (raw: %v); %s", raw, err.Error())
+		}
+		if resp.(map[string]interface{})["id"] != "1" {
+			t.Errorf("expected decoded response (raw: %v); got %v", raw, resp)
+		}
+	}
+}
+
+func TestBrotliResponsesAreDecoded(t *testing.T) {
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	w.Write([]byte(`{"id":"1"}`))
+	w.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "br") {
+			t.Errorf("expected br to be accepted; got %s", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "br")
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	_, resp, err := testClient(t, srv).Get("networks/1", nil)
+	if err != nil {
+		t.Fatalf("failed to decode br response; %s", err.Error())
+	}
+	if resp.(map[string]interface{})["id"] != "1" {
+		t.Errorf("expected decoded response; got %v", resp)
+	}
+}
+
+func TestResponseSizeIsLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":"` + strings.Repeat("a", 64) + `"}`))
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.MaxResponseSize = 32
+	_, _, err := client.Get("networks", nil)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge; got %v", err)
+	}
+
+	client.MaxResponseSize = -1
+	if _, _, err := client.Get("networks", nil); err != nil {
+		t.Errorf("expected unlimited response to be read; got %s", err.Error())
+	}
+
+	srv.Close()
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+	status, _, err := client.Head("networks", nil)
+	if err == nil || status != 0 {
+		t.Errorf("expected dial error without a status; got %d, %v", status, err)
+	}
+}
+
+// pagedServer serves the given items in pages of at most maxRPP items, reporting the total
+// number of results when withTotal is true
+func pagedServer(items []int, maxRPP int, withTotal bool, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		rpp, _ := strconv.Atoi(r.URL.Query().Get("rpp"))
+		if rpp > maxRPP {
+			rpp = maxRPP
+		}
+		start := (page - 1) * rpp
+		end := start + rpp
+		if start > len(items) {
+			start = len(items)
+		}
+		if end > len(items) {
+			end = len(items)
+		}
+		raw, _ := json.Marshal(items[start:end])
+		w.Header().Set("Content-Type", "application/json")
+		if withTotal {
+			w.Header().Set("X-Total-Results-Count", strconv.Itoa(len(items)))
+		}
+		w.Write(raw)
+	}))
+}
+
+func TestPagerWalksAllPages(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var requests int32
+	srv := pagedServer(items, len(items), true, &requests)
+	defer srv.Close()
+
+	pager := testClient(t, srv).Pager("tokens", map[string]interface{}{"rpp": 2})
+	if pager.TotalResults() != nil {
+		t.Errorf("expected nil total results before the first page is fetched")
+	}
+
+	var all []int
+	err := pager.All(context.Background(), &all)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if len(all) != len(items) {
+		t.Errorf("expected %d items; got %v", len(items), all)
+	}
+	if pager.TotalResults() == nil || *pager.TotalResults() != uint64(len(items)) {
+		t.Errorf("expected total results of %d; got %v", len(items), pager.TotalResults())
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 page requests; got %d", requests)
+	}
+}
+
+func TestPagerWalksPagesCappedByServer(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		withTotal bool
+		requests  int32
+	}{
+		{true, 3},
+		{false, 4},
+	} {
+		var requests int32
+		srv := pagedServer(items, 2, tc.withTotal, &requests)
+
+		var all []int
+		err := testClient(t, srv).Pager("tokens", map[string]interface{}{"rpp": 10}).All(context.Background(), &all)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("unexpected error; %s", err.Error())
+		}
+		if len(all) != len(items) {
+			t.Errorf("expected %d items with total %v; got %v", len(items), tc.withTotal, all)
+		}
+		if requests != tc.requests {
+			t.Errorf("expected %d page requests with total %v; got %d", tc.requests, tc.withTotal, requests)
+		}
+	}
+}
+
+func TestGetIntoDecodesTarget(t *testing.T) {
+	body := `{"id":"abc","count":2}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	var target struct {
+		ID    string `json:"id"`
+		Count int    `json:"count"`
+	}
+	status, err := testClient(t, srv).GetInto(context.Background(), "keys/abc", nil, &target)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if status != 200 || target.ID != "abc" || target.Count != 2 {
+		t.Errorf("expected decoded target; got status %d and %+v", status, target)
+	}
+
+	body = `{"id":42}`
+	_, err = testClient(t, srv).GetInto(context.Background(), "keys/abc", nil, &target)
+	if err == nil {
+		t.Errorf("expected decode error to be surfaced")
+	}
+}
+
+func TestMiddlewareChainOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Seen", r.Header.Get("X-Injected"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	Use(trace("global"))
+	defer ResetMiddleware()
+
+	client := testClient(t, srv)
+	client.Middleware = []Middleware{
+		trace("client"),
+		HeaderMiddleware(map[string]string{"X-Injected": "yes"}),
+		LoggingMiddleware(),
+	}
+
+	status, resp, err := client.Head("status", nil)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if status != http.StatusNoContent || resp["X-Seen"][0] != "yes" {
+		t.Errorf("expected injected header to reach the server; got %d %v", status, resp)
+	}
+	if len(order) != 2 || order[0] != "global" || order[1] != "client" {
+		t.Errorf("expected global middleware to wrap client middleware; got %v", order)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, _ := url.Parse("https://ident.provide.services/api/v1/tokens?token=abc&page=2")
+	redacted := redactURL(u, map[string]bool{"token": true})
+	if redacted != "https://ident.provide.services/api/v1/tokens?page=2&token=%5BREDACTED%5D" {
+		t.Errorf("expected token query parameter to be redacted; got %s", redacted)
+	}
+}
+
+type testTokenSource struct {
+	tokens      []string
+	invalidated []string
+}
+
+func (s *testTokenSource) Token(ctx context.Context) (string, error) {
+	return s.tokens[len(s.invalidated)], nil
+}
+
+func (s *testTokenSource) Invalidate(token string) {
+	s.invalidated = append(s.invalidated, token)
+}
+
+func TestTokenSourceReauthorizesOnceOn401(t *testing.T) {
+	var authorizations []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	source := &testTokenSource{tokens: []string{"stale", "fresh"}}
+	client := testClient(t, srv)
+	client.TokenSource = source
+
+	status, _, err := client.Get("status", nil)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("expected 204 status after reauthorization; got %d", status)
+	}
+	if len(source.invalidated) != 1 || source.invalidated[0] != "stale" {
+		t.Errorf("expected stale token to be invalidated; got %v", source.invalidated)
+	}
+	if len(authorizations) != 2 {
+		t.Errorf("expected exactly 2 requests; got %v", authorizations)
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	os.Setenv("TEST_API_HOST", "ident.example.com")
+	defer os.Unsetenv("TEST_API_HOST")
+
+	config := ConfigFromEnv("TEST", "ident.provide.services", "api/v1", "https")
+	if config.Host != "ident.example.com" || config.Path != "api/v1" || config.Scheme != "https" {
+		t.Errorf("expected host to be loaded from the environment; got %+v", config)
+	}
+
+	err := config.Apply(
+		WithURL("http://localhost:8081/api/v2/"),
+		WithToken("abc"),
+		WithUserAgent("provide-go-test"),
+		WithTimeout(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+
+	client := config.Client()
+	if client.buildURL("users") != "http://localhost:8081/api/v2/users" {
+		t.Errorf("expected url option to override the environment; got %s", client.buildURL("users"))
+	}
+	if *client.Token != "abc" || *client.UserAgent != "provide-go-test" || client.Timeout != time.Second {
+		t.Errorf("expected options to be applied to the client; got %+v", client)
+	}
+
+	if config.Apply(WithURL("localhost")) == nil {
+		t.Error("expected error for url without scheme and host")
+	}
+}
+
+func TestPostMultipartStreamsParts(t *testing.T) {
+	const size = 8 << 20
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("expected chunked request body; got content length %d", r.ContentLength)
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("failed to read multipart body; %s", err.Error())
+			return
+		}
+
+		parts := map[string]int64{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("failed to read part; %s", err.Error())
+				return
+			}
+			if part.FormName() == "artifact" && part.FileName() != "artifact.bin" {
+				t.Errorf("expected artifact filename; got %s", part.FileName())
+			}
+			n, _ := io.Copy(ioutil.Discard, part)
+			parts[part.FormName()] = n
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(parts)
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	parts := map[string]int64{}
+	status, err := client.PostMultipartInto(context.Background(), "artifacts", []*MultipartPart{
+		FormField("name", "circuit"),
+		FormFile("artifact", "artifact.bin", "", io.LimitReader(zeroReader{}, size)),
+	}, &parts)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+
+	if status != http.StatusCreated || parts["name"] != 7 || parts["artifact"] != size {
+		t.Errorf("expected all parts to be streamed; got status %d and parts %v", status, parts)
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestPostMultipartHonorsHTTPClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second * 2):
+		}
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.HTTPClient = &http.Client{Timeout: time.Millisecond * 50}
+
+	started := time.Now()
+	if _, _, err := client.PostMultipart(context.Background(), "files", []*MultipartPart{FormField("name", "a")}); err == nil {
+		t.Error("expected the upload to time out")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("expected the timeout of the http client to apply; took %v", elapsed)
+	}
+}
+
+func TestGetStreamDecodesNDJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != ContentTypeNDJSON {
+			t.Errorf("expected accept header to be replaced; got %s", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", ContentTypeNDJSON)
+		for i := 0; i < 3; i++ {
+			w.Write([]byte(`{"message":"line ` + strconv.Itoa(i) + `"}` + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	stream, err := testClient(t, srv).GetStream(context.Background(), "logs", nil, ContentTypeNDJSON)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	defer stream.Close()
+
+	decoder := stream.NDJSON()
+	messages := make([]string, 0)
+	for {
+		var log struct {
+			Message string `json:"message"`
+		}
+		err := decoder.Decode(&log)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("failed to decode line; %s", err.Error())
+		}
+		messages = append(messages, log.Message)
+	}
+
+	if strings.Join(messages, ",") != "line 0,line 1,line 2" {
+		t.Errorf("expected all lines to be decoded; got %v", messages)
+	}
+}
+
+func TestStreamHonorsHTTPClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second * 2):
+		}
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.HTTPClient = &http.Client{Timeout: time.Millisecond * 50}
+
+	started := time.Now()
+	if _, err := client.GetStream(context.Background(), "logs", nil, ContentTypeNDJSON); err == nil {
+		t.Error("expected the stream to time out")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("expected the timeout of the http client to apply; took %v", elapsed)
+	}
+	if client.HTTPClient.Timeout != time.Millisecond*50 {
+		t.Errorf("expected the http client not to be modified; got timeout %v", client.HTTPClient.Timeout)
+	}
+}
+
+func TestEventDecoder(t *testing.T) {
+	decoder := NewEventDecoder(strings.NewReader(": keepalive\n\nid: 1\nevent: log\ndata: {\"a\":\ndata: 1}\n\ndata: second\r\n\r\ndata: incomplete"))
+
+	evt, err := decoder.Next()
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if evt.ID != "1" || evt.Event != "log" || string(evt.Data) != "{\"a\":\n1}" {
+		t.Errorf("unexpected first event; got %+v", evt)
+	}
+
+	evt, err = decoder.Next()
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err.Error())
+	}
+	if evt.ID != "1" || evt.Event != "message" || string(evt.Data) != "second" {
+		t.Errorf("unexpected second event; got %+v", evt)
+	}
+
+	if _, err = decoder.Next(); err != io.EOF {
+		t.Errorf("expected incomplete event to be discarded at EOF; got %v", err)
+	}
+}
+
+func TestCircuitBreakerOpensAndProbes(t *testing.T) {
+	var failing int32 = 1
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	breaker := NewCircuitBreaker(2, 50*time.Millisecond)
+	client := testClient(t, srv)
+	client.CircuitBreaker = breaker
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+	host := srv.Listener.Addr().String()
+
+	for i := 0; i < 2; i++ {
+		client.Get("networks", nil)
+	}
+	if breaker.State(host) != CircuitOpen || breaker.Healthy() {
+		t.Fatalf("expected circuit to open after 2 failures; got %s", breaker.State(host))
+	}
+
+	_, _, err := client.Get("networks", nil)
+	if !errors.Is(err, ErrCircuitOpen) || atomic.LoadInt32(&requests) != 2 {
+		t.Errorf("expected request to be rejected while open; got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if breaker.State(host) != CircuitHalfOpen {
+		t.Fatalf("expected circuit to be half-open after cooldown; got %s", breaker.State(host))
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	if _, _, err := client.Get("networks", nil); err != nil {
+		t.Fatalf("expected probe to succeed; got %s", err.Error())
+	}
+	if breaker.State(host) != CircuitClosed || !breaker.Healthy() {
+		t.Errorf("expected circuit to close after a successful probe; got %s", breaker.State(host))
+	}
+}
+
+func TestCancelAbortsRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("retry") != "" {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second * 5):
+		}
+	}))
+	defer srv.Close()
+
+	client := testClient(t, srv)
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MaxBackoff: time.Second * 5}
+
+	for _, params := range []map[string]interface{}{nil, {"retry": "true"}} {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(time.Millisecond*50, cancel)
+
+		started := time.Now()
+		_, _, err := client.GetWithContext(ctx, "networks", params)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected canceled request to return context.Canceled (params: %v); got %v", params, err)
+		}
+		if elapsed := time.Since(started); elapsed > time.Second {
+			t.Errorf("expected canceled request to return promptly (params: %v); took %v", params, elapsed)
+		}
+	}
+}
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	limiter := NewRateLimiter(20, 1)
+	client := testClient(t, srv)
+	client.RateLimiter = limiter
+
+	started := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Get("networks", nil); err != nil {
+			t.Fatalf("failed to send request; %s", err.Error())
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be spaced by the limiter; took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.GetWithContext(ctx, "networks", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled request to stop waiting; got %v", err)
+	}
+
+	status := limiter.Status()
+	if len(status) != 1 || status[0].Host != srv.Listener.Addr().String() {
+		t.Errorf("expected status of a single host; got %+v", status)
+	}
+}
+
+type testSpan struct {
+	name  string
+	sc    SpanContext
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SpanContext() SpanContext { return s.sc }
+func (s *testSpan) RecordError(err error)    { s.err = err }
+func (s *testSpan) End()                     { s.ended = true }
+
+func (s *testSpan) SetAttributes(attrs map[string]interface{}) {
+	for key, val := range attrs {
+		s.attrs[key] = val
+	}
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, Span) {
+	parent, _ := SpanContextFromContext(ctx)
+	span := &testSpan{name: name, sc: SpanContext{TraceID: parent.TraceID, SpanID: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}, TraceFlags: 1}, attrs: attrs}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestRequestsAreTracedAndMeasured(t *testing.T) {
+	var attempts int32
+	var traceparent atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent.Store(r.Header.Get("Traceparent"))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	tracer := &testTracer{}
+	histogram := NewLatencyHistogram()
+	client := testClient(t, srv)
+	client.Service = "vault"
+	client.Tracer = tracer
+	client.Metrics = histogram
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+
+	parent, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatalf("failed to parse traceparent; %s", err.Error())
+	}
+	ctx := ContextWithSpanContext(context.Background(), parent)
+	client.GetWithContext(ctx, "vaults/9d8e7c4a-1b2c-4d5e-8f90-a1b2c3d4e5f6/keys", nil)
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected a single span for all attempts; got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "GET vaults/{id}/keys" || !span.ended || span.err == nil {
+		t.Errorf("expected ended span with templated route and error; got %+v", span)
+	}
+	if span.attrs["http.response.status_code"] != 404 || span.attrs["http.request.resend_count"] != 1 || span.attrs["peer.service"] != "vault" {
+		t.Errorf("unexpected span attributes; got %v", span.attrs)
+	}
+	if traceparent.Load() != "00-4bf92f3577b34da6a3ce929d0e0e4736-0102030405060708-01" {
+		t.Errorf("expected traceparent of the span to be propagated; got %v", traceparent.Load())
+	}
+
+	snapshot := histogram.Snapshot()
+	if len(snapshot) != 1 || snapshot[0].Count != 1 || snapshot[0].Errors["404"] != 1 || snapshot[0].Route != "vaults/{id}/keys" {
+		t.Errorf("unexpected histogram; got %+v", snapshot)
+	}
+}
+
+func TestCacheServesFreshAndRevalidatesStaleResponses(t *testing.T) {
+	var requests, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/api/v1/networks/1" {
+			w.Header().Set("Cache-Control", "max-age=60")
+		} else if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	cache := NewLRUCache(8)
+	client := testClient(t, srv)
+	client.Cache = cache
+
+	for i := 0; i < 3; i++ {
+		_, resp, err := client.Get("networks/1", nil)
+		if err != nil || resp.(map[string]interface{})["id"] != "1" {
+			t.Fatalf("expected cached response; got %v, %v", resp, err)
+		}
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("expected fresh response to be served from the cache; got %d requests", requests)
+	}
+
+	for i := 0; i < 2; i++ {
+		status, resp, err := client.Get("jwks", nil)
+		if err != nil || status != 200 || resp.(map[string]interface{})["id"] != "1" {
+			t.Fatalf("expected revalidated response; got %d, %v, %v", status, resp, err)
+		}
+	}
+	if atomic.LoadInt32(&notModified) != 1 || atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("expected stale response to be revalidated; got %d requests", requests)
+	}
+
+	key := cacheKey(httptest.NewRequest("GET", srv.URL+"/api/v1/networks/1", nil))
+	if _, ok := cache.Get(key); !ok {
+		t.Fatalf("expected response to be cached using key: %s", key)
+	}
+	client.Put("networks/1", map[string]interface{}{})
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected unsafe request to invalidate the cached response")
+	}
+}
+
+func TestTLSAndProxyOptions(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	for _, tc := range []struct {
+		pin string
+		ok  bool
+	}{
+		{PublicKeyPin(srv.Certificate()), true},
+		{"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", false},
+	} {
+		config := &Config{}
+		err := config.Apply(WithURL(srv.URL+"/api/v1"), WithCABundle(bundle), WithPinnedPublicKeys(tc.pin))
+		if err != nil {
+			t.Fatalf("failed to configure client; %s", err.Error())
+		}
+
+		client := config.Client()
+		client.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+		status, _, err := client.Get("networks", nil)
+		if tc.ok && (err != nil || status != http.StatusNoContent) {
+			t.Errorf("expected request to a pinned server to succeed; got %d, %v", status, err)
+		}
+		if !tc.ok && (err == nil || !strings.Contains(err.Error(), "pinned public key")) {
+			t.Errorf("expected request to an unpinned server to fail; got %v", err)
+		}
+	}
+
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.Host)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	config := &Config{}
+	if err := config.Apply(WithURL("http://nchain.example.com/api/v1"), WithProxy(proxy.URL)); err != nil {
+		t.Fatalf("failed to configure proxy; %s", err.Error())
+	}
+	client := config.Client()
+	if _, _, err := client.Get("networks", nil); err != nil || proxied.Load() != "nchain.example.com" {
+		t.Errorf("expected request to be sent through the proxy; got %v, %v", proxied.Load(), err)
+	}
+
+	if err := config.Apply(WithProxy("ftp://proxy.example.com")); err == nil {
+		t.Error("expected unsupported proxy scheme to be rejected")
+	}
+}
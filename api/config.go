@@ -0,0 +1,380 @@
+package api
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// Config describes the endpoint, transport and credentials used by the client for a
+// single API; a Config is typically loaded from the environment using ConfigFromEnv
+// and refined using functional options
+type Config struct {
+	Host   string
+	Path   string
+	Scheme string
+
+	// Token is the static bearer token used to authorize requests
+	Token *string
+
+	// TokenSource provides the bearer token for each request in place of Token
+	TokenSource TokenSource
+
+	Username *string
+	Password *string
+
+	// UserAgent, when set, is sent as the User-Agent header of each request
+	UserAgent *string
+
+	// Timeout, when positive, overrides the REQUEST_TIMEOUT of the environment
+	Timeout time.Duration
+
+	// TLSClientConfig, when set, is used by the transport in place of the default configuration
+	TLSClientConfig *tls.Config
+
+	HTTPClient      *http.Client
+	Middleware      []Middleware
+	RetryPolicy     *RetryPolicy
+	TransportConfig *TransportConfig
+
+	// MaxResponseSize, when positive, overrides DefaultMaxResponseSize; when negative, response
+	// bodies are read without limit
+	MaxResponseSize int64
+
+	RateLimiter    *RateLimiter
+	CircuitBreaker *CircuitBreaker
+	Cache          ResponseCache
+
+	// Service is the name of the API, used to identify it in traces and metrics
+	Service string
+
+	Tracer  Tracer
+	Metrics Metrics
+}
+
+// Option configures a Config
+type Option func(*Config) error
+
+// ConfigFromEnv resolves the host, path and scheme of an API from the <prefix>_API_HOST,
+// <prefix>_API_PATH and <prefix>_API_SCHEME environment variables, falling back to the
+// given defaults for any which are not set; the service name is the lowercase prefix
+func ConfigFromEnv(prefix, defaultHost, defaultPath, defaultScheme string) *Config {
+	config := &Config{
+		Host:    defaultHost,
+		Path:    defaultPath,
+		Scheme:  defaultScheme,
+		Service: strings.ToLower(prefix),
+	}
+
+	if os.Getenv(fmt.Sprintf("%s_API_HOST", prefix)) != "" {
+		config.Host = os.Getenv(fmt.Sprintf("%s_API_HOST", prefix))
+	}
+
+	if os.Getenv(fmt.Sprintf("%s_API_PATH", prefix)) != "" {
+		config.Path = os.Getenv(fmt.Sprintf("%s_API_PATH", prefix))
+	}
+
+	if os.Getenv(fmt.Sprintf("%s_API_SCHEME", prefix)) != "" {
+		config.Scheme = os.Getenv(fmt.Sprintf("%s_API_SCHEME", prefix))
+	}
+
+	return config
+}
+
+// Apply applies the given options to the config
+func (c *Config) Apply(opts ...Option) error {
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Client returns a Client configured in accordance with the config
+func (c *Config) Client() Client {
+	return Client{
+		Host:            c.Host,
+		Path:            c.Path,
+		Scheme:          c.Scheme,
+		Token:           c.Token,
+		TokenSource:     c.TokenSource,
+		Username:        c.Username,
+		Password:        c.Password,
+		UserAgent:       c.UserAgent,
+		Timeout:         c.Timeout,
+		TLSClientConfig: c.TLSClientConfig,
+		HTTPClient:      c.HTTPClient,
+		Middleware:      c.Middleware,
+		RetryPolicy:     c.RetryPolicy,
+		TransportConfig: c.TransportConfig,
+		MaxResponseSize: c.MaxResponseSize,
+		RateLimiter:     c.RateLimiter,
+		CircuitBreaker:  c.CircuitBreaker,
+		Cache:           c.Cache,
+		Service:         c.Service,
+		Tracer:          c.Tracer,
+		Metrics:         c.Metrics,
+	}
+}
+
+// WithURL sets the scheme, host and path of the API from the given base url,
+// i.e., https://ident.provide.services/api/v1
+func WithURL(baseURL string) Option {
+	return func(c *Config) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse API url: %s; %s", baseURL, err.Error())
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("failed to parse API url: %s; scheme and host are required", baseURL)
+		}
+		c.Scheme = u.Scheme
+		c.Host = u.Host
+		c.Path = strings.Trim(u.Path, "/")
+		return nil
+	}
+}
+
+// WithToken sets the static bearer token used to authorize requests; an empty token
+// clears any previously-configured token
+func WithToken(token string) Option {
+	return func(c *Config) error {
+		c.Token = common.StringOrNil(token)
+		return nil
+	}
+}
+
+// WithTokenSource sets the TokenSource which provides bearer tokens for requests
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(c *Config) error {
+		c.TokenSource = tokenSource
+		return nil
+	}
+}
+
+// WithBasicAuth sets the credentials used for HTTP basic authorization
+func WithBasicAuth(username, password string) Option {
+	return func(c *Config) error {
+		c.Username = common.StringOrNil(username)
+		c.Password = common.StringOrNil(password)
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) error {
+		c.UserAgent = common.StringOrNil(userAgent)
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout for each request
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		c.Timeout = timeout
+		return nil
+	}
+}
+
+// WithTLSClientConfig sets the TLS configuration used to connect to the API
+func WithTLSClientConfig(tlsClientConfig *tls.Config) Option {
+	return func(c *Config) error {
+		c.TLSClientConfig = tlsClientConfig
+		return nil
+	}
+}
+
+// WithHTTPClient sets the *http.Client used to send requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Config) error {
+		c.HTTPClient = httpClient
+		return nil
+	}
+}
+
+// WithMiddleware appends the given middleware to the chain of the client
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Config) error {
+		c.Middleware = append(c.Middleware, mw...)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the client
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Config) error {
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithTransportConfig sets the configuration of the pooled transport used by the client
+func WithTransportConfig(config *TransportConfig) Option {
+	return func(c *Config) error {
+		c.TransportConfig = config
+		return nil
+	}
+}
+
+// WithMaxResponseSize sets the maximum decoded size, in bytes, of a response body read into
+// memory; a negative size disables the limit
+func WithMaxResponseSize(size int64) Option {
+	return func(c *Config) error {
+		c.MaxResponseSize = size
+		return nil
+	}
+}
+
+// WithRateLimiter limits the rate of requests sent by the client to each host; the limiter
+// may be shared with other clients
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Config) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithCircuitBreaker sets the circuit breaker of the client; the breaker may be shared with
+// other clients
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Config) error {
+		c.CircuitBreaker = breaker
+		return nil
+	}
+}
+
+// WithTracer sets the tracer which starts a span for each request sent by the client
+func WithTracer(tracer Tracer) Option {
+	return func(c *Config) error {
+		c.Tracer = tracer
+		return nil
+	}
+}
+
+// WithMetrics sets the recorder of the latency and outcome of each request sent by the client
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Config) error {
+		c.Metrics = metrics
+		return nil
+	}
+}
+
+// WithCache enables caching of the responses to GET requests using the given cache, which may
+// be shared with other clients
+func WithCache(cache ResponseCache) Option {
+	return func(c *Config) error {
+		c.Cache = cache
+		return nil
+	}
+}
+
+// WithCABundle verifies the certificate of the API using only the certificate authorities in the
+// given PEM-encoded bundle, rather than the system roots
+func WithCABundle(pemCerts []byte) Option {
+	return func(c *Config) error {
+		pool, err := parseCABundle(pemCerts)
+		if err != nil {
+			return err
+		}
+
+		c.TLSClientConfig = cloneTLSClientConfig(c.TLSClientConfig)
+		c.TLSClientConfig.RootCAs = pool
+		return nil
+	}
+}
+
+// WithCABundleFile verifies the certificate of the API using only the certificate authorities in
+// the PEM-encoded bundle at the given path
+func WithCABundleFile(path string) Option {
+	return func(c *Config) error {
+		pemCerts, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle; %s", err.Error())
+		}
+		return WithCABundle(pemCerts)(c)
+	}
+}
+
+// WithClientCertificate presents the given PEM-encoded certificate and private key to the API
+// for mutual TLS authentication
+func WithClientCertificate(certPEM, keyPEM []byte) Option {
+	return func(c *Config) error {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("failed to parse client certificate; %s", err.Error())
+		}
+
+		c.TLSClientConfig = cloneTLSClientConfig(c.TLSClientConfig)
+		c.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		return nil
+	}
+}
+
+// WithClientCertificateFile presents the PEM-encoded certificate and private key in the given
+// files to the API for mutual TLS authentication
+func WithClientCertificateFile(certFile, keyFile string) Option {
+	return func(c *Config) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate; %s", err.Error())
+		}
+
+		c.TLSClientConfig = cloneTLSClientConfig(c.TLSClientConfig)
+		c.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		return nil
+	}
+}
+
+// WithPinnedPublicKeys rejects connections to the API unless its certificate chain includes a
+// public key having one of the given pins; see PublicKeyPin
+func WithPinnedPublicKeys(pins ...string) Option {
+	return func(c *Config) error {
+		if len(pins) == 0 {
+			return errors.New("at least one public key pin is required")
+		}
+
+		c.TLSClientConfig = cloneTLSClientConfig(c.TLSClientConfig)
+		c.TLSClientConfig.VerifyPeerCertificate = verifyPinnedPublicKeys(pins, c.TLSClientConfig.VerifyPeerCertificate)
+		return nil
+	}
+}
+
+// WithProxy sends requests through the http, https or socks5 proxy at the given url
+func WithProxy(proxyURL string) Option {
+	return func(c *Config) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse proxy url; %s", err.Error())
+		}
+
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("unsupported proxy scheme: %s", u.Scheme)
+		}
+
+		c.TransportConfig = cloneTransportConfig(c.TransportConfig)
+		c.TransportConfig.ProxyURL = proxyURL
+		return nil
+	}
+}
+
+// WithProxyFromEnvironment sends requests through the proxy configured using the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables
+func WithProxyFromEnvironment() Option {
+	return func(c *Config) error {
+		c.TransportConfig = cloneTransportConfig(c.TransportConfig)
+		c.TransportConfig.ProxyFromEnvironment = true
+		return nil
+	}
+}
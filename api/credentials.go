@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TokenSource provides the bearer tokens used to authorize API requests; implementations
+// are expected to cache tokens and must be safe for concurrent use
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenInvalidator may be implemented by a TokenSource which caches tokens; when a request
+// authorized using a token from the source is rejected with a 401 status, Invalidate is
+// called with the rejected token before the request is retried once with a fresh token
+type TokenInvalidator interface {
+	Invalidate(token string)
+}
+
+// StaticTokenSource returns a TokenSource which always provides the given token
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// authorization resolves the authorization header value for a request, preferring the
+// token source over a static token and basic auth credentials
+func (c *Client) authorization(ctx context.Context) (*string, error) {
+	if c.TokenSource != nil {
+		token, err := c.TokenSource.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve bearer token from token source; %w", err)
+		}
+		return bearerAuthorization(token), nil
+	} else if c.Token != nil {
+		return bearerAuthorization(*c.Token), nil
+	} else if c.Username != nil && c.Password != nil {
+		authorization := buildBasicAuthorizationHeader(*c.Username, *c.Password)
+		return &authorization, nil
+	}
+
+	return nil, nil
+}
+
+// reauthorize invalidates the token rejected for the given authorization header, if the
+// token source supports invalidation, and resolves a fresh authorization header value
+func (c *Client) reauthorize(ctx context.Context, authorization string) (*string, error) {
+	if invalidator, invalidatorOk := c.TokenSource.(TokenInvalidator); invalidatorOk {
+		invalidator.Invalidate(strings.TrimPrefix(authorization, bearerAuthorizationPrefix))
+	}
+	return c.authorization(ctx)
+}
+
+const bearerAuthorizationPrefix = "bearer "
+
+func bearerAuthorization(token string) *string {
+	authorization := fmt.Sprintf("%s%s", bearerAuthorizationPrefix, token)
+	return &authorization
+}
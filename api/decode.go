@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetInto constructs and synchronously sends an API GET request using the given context,
+// decoding the JSON response directly into target; target may point to a struct or a slice
+func (c *Client) GetInto(ctx context.Context, uri string, params map[string]interface{}, target interface{}) (status int, err error) {
+	return c.sendRequestInto(ctx, "GET", uri, params, target)
+}
+
+// PatchInto constructs and synchronously sends an API PATCH request using the given context,
+// decoding the JSON response directly into target
+func (c *Client) PatchInto(ctx context.Context, uri string, params map[string]interface{}, target interface{}) (status int, err error) {
+	return c.sendRequestInto(ctx, "PATCH", uri, params, target)
+}
+
+// PostInto constructs and synchronously sends an API POST request using the given context,
+// decoding the JSON response directly into target
+func (c *Client) PostInto(ctx context.Context, uri string, params map[string]interface{}, target interface{}) (status int, err error) {
+	return c.sendRequestInto(ctx, "POST", uri, params, target)
+}
+
+// PutInto constructs and synchronously sends an API PUT request using the given context,
+// decoding the JSON response directly into target
+func (c *Client) PutInto(ctx context.Context, uri string, params map[string]interface{}, target interface{}) (status int, err error) {
+	return c.sendRequestInto(ctx, "PUT", uri, params, target)
+}
+
+// DeleteInto constructs and synchronously sends an API DELETE request using the given context,
+// decoding the JSON response, if any, directly into target
+func (c *Client) DeleteInto(ctx context.Context, uri string, target interface{}) (status int, err error) {
+	return c.sendRequestInto(ctx, "DELETE", uri, nil, target)
+}
+
+func (c *Client) sendRequestInto(ctx context.Context, method, uri string, params map[string]interface{}, target interface{}) (status int, err error) {
+	url := c.buildURL(uri)
+	resp, err := c.sendRequest(ctx, method, url, defaultContentType, params)
+	if err != nil {
+		return 0, err
+	}
+	return c.decodeResponse(resp, target)
+}
+
+// decodeResponse reads the given response and unmarshals its body into target; a nil target
+// discards the body. An *APIError is returned for 4xx and 5xx responses and the target is left
+// untouched in that case.
+func (c *Client) decodeResponse(resp *http.Response, target interface{}) (status int, err error) {
+	body, err := c.readResponse(resp)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, NewAPIError(resp, body)
+	}
+
+	if target != nil && len(body) > 0 {
+		err = json.Unmarshal(body, target)
+		if err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to unmarshal %v-byte HTTP %s response from %s; %s", len(body), resp.Request.Method, resp.Request.URL.String(), err.Error())
+		}
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// ContentDecoder returns a reader which decodes a response body having the content encoding
+// for which the decoder is registered
+type ContentDecoder func(body io.Reader) (io.ReadCloser, error)
+
+var (
+	contentDecoders = map[string]ContentDecoder{
+		"br":      decodeBrotli,
+		"deflate": decodeDeflate,
+		"gzip":    decodeGzip,
+	}
+	contentDecodersMutex sync.RWMutex
+)
+
+// RegisterContentDecoder registers a decoder for the given content encoding, which is then
+// advertised in the Accept-Encoding header of every request; gzip, deflate and br are
+// registered by default
+func RegisterContentDecoder(encoding string, decoder ContentDecoder) {
+	contentDecodersMutex.Lock()
+	defer contentDecodersMutex.Unlock()
+	contentDecoders[strings.ToLower(encoding)] = decoder
+}
+
+// acceptEncoding returns the value of the Accept-Encoding header, listing gzip, deflate and br
+// followed by any additionally registered encodings
+func acceptEncoding() string {
+	contentDecodersMutex.RLock()
+	defer contentDecodersMutex.RUnlock()
+
+	encodings := []string{"gzip", "deflate", "br"}
+	registered := make([]string, 0, len(contentDecoders))
+	for encoding := range contentDecoders {
+		if encoding != "gzip" && encoding != "deflate" && encoding != "br" {
+			registered = append(registered, encoding)
+		}
+	}
+	sort.Strings(registered)
+
+	return strings.Join(append(encodings, registered...), ", ")
+}
+
+// ResponseReader returns a reader which decodes the body of the given response using the
+// registered content decoders, in accordance with its content encoding; closing the reader
+// closes the body. Multiple encodings are decoded in the reverse of the order in which they
+// were applied.
+func ResponseReader(resp *http.Response) (io.ReadCloser, error) {
+	header := resp.Header.Get("Content-Encoding")
+	if header == "" {
+		return resp.Body, nil
+	}
+
+	encodings := strings.Split(header, ",")
+	closers := []io.Closer{resp.Body}
+	var reader io.Reader = resp.Body
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := strings.ToLower(strings.TrimSpace(encodings[i]))
+		if encoding == "" || encoding == "identity" {
+			continue
+		}
+
+		contentDecodersMutex.RLock()
+		decoder, ok := contentDecoders[encoding]
+		contentDecodersMutex.RUnlock()
+
+		if !ok {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+		}
+
+		decoded, err := decoder(reader)
+		if err != nil {
+			resp.Body.Close()
+			common.Log.Warningf("failed to read %s-encoded HTTP response stream; %s", encoding, err.Error())
+			return nil, err
+		}
+
+		reader = decoded
+		closers = append(closers, decoded)
+	}
+
+	return &decodingReadCloser{Reader: reader, closers: closers}, nil
+}
+
+// decodingReadCloser reads from the decoders wrapping a response body and closes each of them
+// along with the body
+type decodingReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *decodingReadCloser) Close() error {
+	var err error
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if closeErr := r.closers[i].Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func decodeBrotli(body io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(brotli.NewReader(body)), nil
+}
+
+func decodeGzip(body io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(body)
+}
+
+// decodeDeflate decodes the zlib format required for the deflate content encoding, falling
+// back to raw deflate, which is sent by some servers in its place
+func decodeDeflate(body io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(body)
+	header, err := buffered.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
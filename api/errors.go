@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+var (
+	// ErrBadRequest is matched by an *APIError having a 400 status
+	ErrBadRequest = errors.New("bad request")
+
+	// ErrUnauthorized is matched by an *APIError having a 401 status
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden is matched by an *APIError having a 403 status
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNotFound is matched by an *APIError having a 404 status
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is matched by an *APIError having a 409 status
+	ErrConflict = errors.New("conflict")
+
+	// ErrUnprocessableEntity is matched by an *APIError having a 422 status
+	ErrUnprocessableEntity = errors.New("unprocessable entity")
+
+	// ErrTooManyRequests is matched by an *APIError having a 429 status
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrServerError is matched by an *APIError having a 5xx status
+	ErrServerError = errors.New("server error")
+
+	// ErrResponseTooLarge is returned when a response body exceeds the maximum response size
+	ErrResponseTooLarge = errors.New("response body exceeds maximum size")
+)
+
+// APIError is returned when an API responds to a request with a 4xx or 5xx status; use
+// errors.Is with one of the Err* sentinels in this package to branch on the kind of failure
+type APIError struct {
+	Status    int      `json:"status"`
+	Method    string   `json:"method"`
+	URL       string   `json:"url"`
+	RequestID *string  `json:"request_id,omitempty"`
+	Errors    []*Error `json:"errors,omitempty"`
+}
+
+// NewAPIError returns an *APIError for the given response; the errors array is decoded from the
+// given response body, which may be nil
+func NewAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Status: resp.StatusCode,
+		Errors: make([]*Error, 0),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.URL = resp.Request.URL.String()
+		}
+	}
+
+	if requestID := resp.Header.Get(requestIDHeader); requestID != "" {
+		apiErr.RequestID = &requestID
+	}
+
+	if len(body) > 0 {
+		var errResponse struct {
+			Errors  []*Error `json:"errors"`
+			Message *string  `json:"message"`
+		}
+		if err := json.Unmarshal(body, &errResponse); err == nil {
+			for _, e := range errResponse.Errors {
+				if e != nil {
+					apiErr.Errors = append(apiErr.Errors, e)
+				}
+			}
+			if len(apiErr.Errors) == 0 && errResponse.Message != nil {
+				apiErr.Errors = append(apiErr.Errors, &Error{
+					Message: errResponse.Message,
+					Status:  &apiErr.Status,
+				})
+			}
+		}
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("HTTP %s request failed: %s; status: %d", e.Method, e.URL, e.Status)
+
+	messages := make([]string, 0)
+	for _, err := range e.Errors {
+		if err.Message != nil {
+			messages = append(messages, *err.Message)
+		}
+	}
+	if len(messages) > 0 {
+		msg = fmt.Sprintf("%s; %s", msg, strings.Join(messages, "; "))
+	}
+
+	if e.RequestID != nil {
+		msg = fmt.Sprintf("%s; request id: %s", msg, *e.RequestID)
+	}
+
+	return msg
+}
+
+// Is returns true if the target is the sentinel error for the status of the API error
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.Status == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrConflict:
+		return e.Status == http.StatusConflict
+	case ErrUnprocessableEntity:
+		return e.Status == http.StatusUnprocessableEntity
+	case ErrTooManyRequests:
+		return e.Status == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.Status >= http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package fake
+
+import (
+	"net/http"
+)
+
+func (s *Server) baselineRouter() http.Handler {
+	rt := s.newRouter()
+
+	rt.api("PUT", "config", s.configureStack)
+
+	rt.api("GET", "workgroups", s.list("workgroups", ""))
+	rt.api("POST", "workgroups", s.create("workgroups", http.StatusOK, nil))
+	rt.api("POST", "workgroups/:id", s.update("workgroups", "id", http.StatusNoContent))
+
+	rt.api("GET", "workflows", s.list("workflows", ""))
+	rt.api("POST", "workflows", s.create("workflows", http.StatusOK, nil))
+
+	rt.api("GET", "worksteps", s.list("worksteps", ""))
+	rt.api("POST", "worksteps", s.create("worksteps", http.StatusOK, nil))
+
+	rt.api("POST", "objects", s.create("objects", http.StatusAccepted, nil))
+	rt.api("PUT", "objects/:id", s.update("objects", "id", http.StatusAccepted))
+
+	return rt
+}
+
+// configureStack merges the request params into the baseline stack configuration, which is
+// stored as the single object in the config collection
+func (s *Server) configureStack(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if !s.store.update("config", "config", req) {
+		req["id"] = "config"
+		s.store.insert("config", req)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,793 @@
+package fake
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const fakeIssuer = "https://ident.fake.provide.services"
+const accessTokenTTL = time.Hour
+const refreshTokenTTL = 30 * 24 * time.Hour
+const authorizationCodeTTL = 10 * time.Minute
+const scopeOfflineAccess = "offline_access"
+
+func (s *Server) identRouter() http.Handler {
+	rt := s.newRouter()
+
+	rt.public("GET", "status", s.status)
+	rt.public("GET", ".well-known/keys", s.jwks)
+	rt.public("POST", fmt.Sprintf("%s/authenticate", apiPath), s.authenticate)
+	rt.public("POST", fmt.Sprintf("%s/tokens", apiPath), s.createToken)
+	rt.public("POST", fmt.Sprintf("%s/users", apiPath), s.createUser)
+	rt.public("POST", fmt.Sprintf("%s/users/reset_password", apiPath), s.requestPasswordReset)
+	rt.public("POST", fmt.Sprintf("%s/users/reset_password/:token", apiPath), s.resetPassword)
+
+	rt.api("GET", "applications", s.list("applications", ""))
+	rt.api("POST", "applications", s.create("applications", http.StatusCreated, nil))
+	rt.api("GET", "applications/:id", s.get("applications", "id"))
+	rt.api("PUT", "applications/:id", s.update("applications", "id", http.StatusNoContent))
+	rt.api("DELETE", "applications/:id", s.delete("applications", "id"))
+	rt.api("GET", "applications/:id/tokens", s.list("tokens", "application_id"))
+	rt.api("GET", "applications/:id/invitations", s.list("invitations", "application_id"))
+	rt.api("GET", "applications/:id/organizations", s.listAssociated("application_organizations", "application_id", "organization_id", "organizations"))
+	rt.api("POST", "applications/:id/organizations", s.associate("application_organizations", "application_id", "organization_id"))
+	rt.api("DELETE", "applications/:id/organizations/:member_id", s.dissociate("application_organizations", "application_id", "organization_id"))
+	rt.api("GET", "applications/:id/users", s.listAssociated("application_users", "application_id", "user_id", "users"))
+	rt.api("POST", "applications/:id/users", s.associate("application_users", "application_id", "user_id"))
+	rt.api("PUT", "applications/:id/users/:member_id", s.updateAssociation("application_users", "application_id", "user_id"))
+	rt.api("DELETE", "applications/:id/users/:member_id", s.dissociate("application_users", "application_id", "user_id"))
+
+	rt.api("GET", "organizations", s.list("organizations", ""))
+	rt.api("POST", "organizations", s.create("organizations", http.StatusCreated, nil))
+	rt.api("GET", "organizations/:id", s.get("organizations", "id"))
+	rt.api("PUT", "organizations/:id", s.update("organizations", "id", http.StatusNoContent))
+	rt.api("DELETE", "organizations/:id", s.delete("organizations", "id"))
+	rt.api("GET", "organizations/:id/applications", s.listAssociated("application_organizations", "organization_id", "application_id", "applications"))
+	rt.api("GET", "organizations/:id/invitations", s.list("invitations", "organization_id"))
+	rt.api("GET", "organizations/:id/users", s.listAssociated("organization_users", "organization_id", "user_id", "users"))
+	rt.api("POST", "organizations/:id/users", s.associate("organization_users", "organization_id", "user_id"))
+	rt.api("PUT", "organizations/:id/users/:member_id", s.updateAssociation("organization_users", "organization_id", "user_id"))
+	rt.api("DELETE", "organizations/:id/users/:member_id", s.dissociate("organization_users", "organization_id", "user_id"))
+
+	rt.api("POST", "invitations", s.create("invitations", http.StatusNoContent, nil))
+	rt.api("GET", "invitations/:id", s.get("invitations", "id"))
+	rt.api("POST", "invitations/:id/accept", s.acceptInvitation)
+
+	rt.api("GET", "oauth/authorize", s.authorize)
+
+	rt.api("GET", "tokens", s.list("tokens", ""))
+	rt.api("GET", "tokens/:id", s.get("tokens", "id"))
+	rt.api("DELETE", "tokens/:id", s.revokeToken)
+
+	rt.api("GET", "users", s.list("users", ""))
+	rt.api("GET", "users/:id", s.get("users", "id"))
+	rt.api("PUT", "users/:id", s.updateUser)
+	rt.api("DELETE", "users/:id", s.delete("users", "id"))
+
+	return rt
+}
+
+func (s *Server) status(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// jwks writes the public key used to sign the bearer tokens issued by the fake ident server
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	pub := &s.signingKey.PublicKey
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	fingerprint := sha256.Sum256(der)
+
+	writeJSON(w, http.StatusOK, []map[string]interface{}{
+		{
+			"kid":         s.keyID,
+			"use":         "sig",
+			"n":           base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":           base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			"fingerprint": fmt.Sprintf("%x", fingerprint),
+			"public_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		},
+	})
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	email := strings.ToLower(stringParam(req, "email"))
+	users := s.store.find("users", func(usr map[string]interface{}) bool {
+		return strings.ToLower(fmt.Sprintf("%v", usr["email"])) == email
+	})
+	if len(users) == 0 {
+		writeError(w, http.StatusUnauthorized, "authentication failed")
+		return
+	}
+	usr := users[0]
+
+	s.mutex.Lock()
+	passwd := s.passwords[usr["id"].(string)]
+	s.mutex.Unlock()
+	if passwd != stringParam(req, "password") {
+		writeError(w, http.StatusUnauthorized, "authentication failed")
+		return
+	}
+
+	subject := fmt.Sprintf("user:%s", usr["id"])
+	var tkn map[string]interface{}
+	if stringParam(req, "scope") == scopeOfflineAccess {
+		tkn, err = s.issueRefreshableToken(subject, map[string]interface{}{"user_id": usr["id"]})
+	} else {
+		tkn, err = s.issueToken(subject, accessTokenTTL)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"user":  usr,
+		"token": tkn,
+	})
+}
+
+// createToken vends a token for the application, organization or user given in the request
+// params or, when an OAuth grant type is given, authorizes a token using the grant
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	switch stringParam(req, "grant_type") {
+	case "refresh_token":
+		s.refreshTokenGrant(w, r, req)
+		return
+	case "client_credentials":
+		s.clientCredentialsGrant(w, r, req)
+		return
+	case "authorization_code":
+		s.authorizationCodeGrant(w, req)
+		return
+	}
+
+	bearer, ok := s.bearerClaims(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	subject := bearer["sub"].(string)
+	fields := map[string]interface{}{}
+	for _, field := range []string{"application_id", "organization_id", "user_id"} {
+		if id := stringParam(req, field); id != "" {
+			subject = fmt.Sprintf("%s:%s", strings.TrimSuffix(field, "_id"), id)
+			fields[field] = id
+		}
+	}
+
+	var tkn map[string]interface{}
+	if stringParam(req, "scope") == scopeOfflineAccess {
+		tkn, err = s.issueRefreshableToken(subject, fields)
+	} else {
+		tkn, err = s.issueToken(subject, accessTokenTTL)
+		if err == nil && len(fields) > 0 {
+			s.store.update("tokens", tkn["id"].(string), fields)
+			tkn, _ = s.store.get("tokens", tkn["id"].(string))
+		}
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tkn)
+}
+
+// refreshTokenGrant exchanges the refresh token given in the params or as the bearer token for
+// a new access token; the refresh token is rotated, so the given refresh token is revoked
+func (s *Server) refreshTokenGrant(w http.ResponseWriter, r *http.Request, req map[string]interface{}) {
+	refreshToken := stringParam(req, "refresh_token")
+	if refreshToken == "" {
+		refreshToken = bearerToken(r)
+	}
+
+	claims, err := s.verifyToken(refreshToken)
+	if err != nil || claims["scope"] != scopeOfflineAccess {
+		writeError(w, http.StatusUnauthorized, "invalid refresh token")
+		return
+	}
+
+	s.mutex.Lock()
+	s.revoked[claims["jti"].(string)] = true
+	delete(s.refreshIDs, claims["jti"].(string))
+	s.mutex.Unlock()
+
+	tkn, err := s.issueRefreshableToken(claims["sub"].(string), nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tkn)
+}
+
+// clientCredentialsGrant authorizes an access token for the application identified by the
+// client id; the client secret must be a valid token vended for the application
+func (s *Server) clientCredentialsGrant(w http.ResponseWriter, r *http.Request, req map[string]interface{}) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = stringParam(req, "client_id")
+		clientSecret = stringParam(req, "client_secret")
+	}
+
+	subject := fmt.Sprintf("application:%s", clientID)
+	claims, err := s.verifyToken(clientSecret)
+	if err != nil || claims["sub"] != subject {
+		writeError(w, http.StatusUnauthorized, "invalid client credentials")
+		return
+	}
+	if _, ok := s.store.get("applications", clientID); !ok {
+		writeError(w, http.StatusUnauthorized, "invalid client credentials")
+		return
+	}
+
+	tkn, err := s.issueAccessToken(subject, stringParam(req, "scope"), map[string]interface{}{
+		"application_id": clientID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tkn)
+}
+
+// authorizationCode is an authorization code issued by the authorize endpoint
+type authorizationCode struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	scope       string
+	subject     string
+	expiresAt   time.Time
+}
+
+// authorize issues an authorization code for the application identified by the client id on
+// behalf of the user authorizing the request, and redirects to the redirect uri; since there is
+// no consent page, the user is assumed to consent
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	bearer, _ := s.bearerClaims(r)
+	query := r.URL.Query()
+
+	if query.Get("response_type") != "code" {
+		writeError(w, http.StatusBadRequest, "unsupported response type")
+		return
+	}
+	if _, ok := s.store.get("applications", query.Get("client_id")); !ok {
+		writeError(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		writeError(w, http.StatusBadRequest, "S256 code challenge required")
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		writeError(w, http.StatusBadRequest, "invalid redirect uri")
+		return
+	}
+
+	code := newID()
+	s.mutex.Lock()
+	s.authCodes[code] = &authorizationCode{
+		clientID:    query.Get("client_id"),
+		redirectURI: query.Get("redirect_uri"),
+		challenge:   query.Get("code_challenge"),
+		scope:       query.Get("scope"),
+		subject:     bearer["sub"].(string),
+		expiresAt:   time.Now().Add(authorizationCodeTTL),
+	}
+	s.mutex.Unlock()
+
+	redirectQuery := redirectURI.Query()
+	redirectQuery.Set("code", code)
+	if state := query.Get("state"); state != "" {
+		redirectQuery.Set("state", state)
+	}
+	redirectURI.RawQuery = redirectQuery.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// authorizationCodeGrant exchanges an authorization code for a token on behalf of the user who
+// authorized it; the code may be exchanged once, using the verifier of its code challenge
+func (s *Server) authorizationCodeGrant(w http.ResponseWriter, req map[string]interface{}) {
+	s.mutex.Lock()
+	code, ok := s.authCodes[stringParam(req, "code")]
+	delete(s.authCodes, stringParam(req, "code"))
+	s.mutex.Unlock()
+
+	if !ok || time.Now().After(code.expiresAt) ||
+		code.clientID != stringParam(req, "client_id") ||
+		code.redirectURI != stringParam(req, "redirect_uri") {
+		writeError(w, http.StatusBadRequest, "invalid authorization code")
+		return
+	}
+
+	digest := sha256.Sum256([]byte(stringParam(req, "code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(digest[:]) != code.challenge {
+		writeError(w, http.StatusBadRequest, "invalid code verifier")
+		return
+	}
+
+	fields := map[string]interface{}{
+		"application_id": code.clientID,
+		"user_id":        strings.TrimPrefix(code.subject, "user:"),
+	}
+
+	var tkn map[string]interface{}
+	var err error
+	if containsScope(code.scope, scopeOfflineAccess) {
+		tkn, err = s.issueRefreshableToken(code.subject, fields)
+	} else {
+		tkn, err = s.issueAccessToken(code.subject, code.scope, fields)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tkn)
+}
+
+func containsScope(scope, target string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
+
+// revokeToken revokes the stored token, or the refresh token, having the given id
+func (s *Server) revokeToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mutex.Lock()
+	refreshToken := s.refreshIDs[params["id"]]
+	delete(s.refreshIDs, params["id"])
+	s.mutex.Unlock()
+
+	if !s.store.delete("tokens", params["id"]) && !refreshToken {
+		writeError(w, http.StatusNotFound, "token not found")
+		return
+	}
+
+	s.mutex.Lock()
+	s.revoked[params["id"]] = true
+	s.mutex.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// acceptInvitation associates the user authorizing the request with the organization and the
+// application of the invitation, which is then removed
+func (s *Server) acceptInvitation(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	invite, ok := s.store.get("invitations", params["id"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "invitation not found")
+		return
+	}
+
+	bearer, _ := s.bearerClaims(r)
+	subject := bearer["sub"].(string)
+	if !strings.HasPrefix(subject, "user:") {
+		writeError(w, http.StatusForbidden, "invitations may only be accepted by users")
+		return
+	}
+	userID := strings.TrimPrefix(subject, "user:")
+
+	for collection, field := range map[string]string{
+		"application_users":  "application_id",
+		"organization_users": "organization_id",
+	} {
+		if parentID := stringParam(invite, field); parentID != "" {
+			s.store.insert(collection, map[string]interface{}{
+				"id":          fmt.Sprintf("%s:%s", parentID, userID),
+				field:         parentID,
+				"user_id":     userID,
+				"permissions": invite["permissions"],
+			})
+		}
+	}
+	s.store.delete("invitations", params["id"])
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	email := strings.ToLower(stringParam(req, "email"))
+	if email == "" {
+		writeError(w, http.StatusUnprocessableEntity, "email is required")
+		return
+	}
+
+	existing := s.store.find("users", func(usr map[string]interface{}) bool {
+		return usr["email"] == email
+	})
+	if len(existing) > 0 {
+		writeError(w, http.StatusConflict, "user exists")
+		return
+	}
+
+	passwd := stringParam(req, "password")
+	delete(req, "password")
+	req["email"] = email
+
+	usr := s.store.insert("users", req)
+	s.mutex.Lock()
+	s.passwords[usr["id"].(string)] = passwd
+	s.mutex.Unlock()
+
+	writeJSON(w, http.StatusCreated, usr)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	passwd, hasPassword := req["password"].(string)
+	delete(req, "password")
+
+	if !s.store.update("users", params["id"], req) {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	if hasPassword {
+		s.mutex.Lock()
+		s.passwords[params["id"]] = passwd
+		s.mutex.Unlock()
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requestPasswordReset records a reset token for the user with the given email, if one exists;
+// since no email is sent, tests obtain the token using ResetTokens
+func (s *Server) requestPasswordReset(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	email := strings.ToLower(stringParam(req, "email"))
+	for _, usr := range s.store.find("users", func(usr map[string]interface{}) bool {
+		return usr["email"] == email
+	}) {
+		s.store.insert("reset_password_tokens", map[string]interface{}{
+			"user_id": usr["id"],
+			"email":   email,
+		})
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resetPassword(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	reset, ok := s.store.get("reset_password_tokens", params["token"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "reset password token not found")
+		return
+	}
+	s.store.delete("reset_password_tokens", params["token"])
+
+	s.mutex.Lock()
+	s.passwords[reset["user_id"].(string)] = stringParam(req, "password")
+	s.mutex.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetTokens returns the password reset tokens requested for the user with the given email
+// which have not yet been used
+func (s *Server) ResetTokens(email string) []string {
+	email = strings.ToLower(email)
+	tokens := make([]string, 0)
+	for _, reset := range s.store.find("reset_password_tokens", func(reset map[string]interface{}) bool {
+		return reset["email"] == email
+	}) {
+		tokens = append(tokens, reset["id"].(string))
+	}
+	return tokens
+}
+
+// associate returns a handler which relates the member identified in the request params by
+// memberField to the parent identified by the id path parameter
+func (s *Server) associate(collection, parentField, memberField string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req, err := readParams(r)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		memberID := stringParam(req, memberField)
+		if memberID == "" {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s is required", memberField))
+			return
+		}
+
+		req["id"] = fmt.Sprintf("%s:%s", params["id"], memberID)
+		req[parentField] = params["id"]
+		s.store.insert(collection, req)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// listAssociated returns a handler which lists the members of the parent identified by the
+// id path parameter; each member is merged with the fields of its association
+func (s *Server) listAssociated(collection, parentField, memberField, memberCollection string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		members := make([]map[string]interface{}, 0)
+		for _, assoc := range s.store.find(collection, func(assoc map[string]interface{}) bool {
+			return assoc[parentField] == params["id"]
+		}) {
+			member, ok := s.store.get(memberCollection, assoc[memberField].(string))
+			if !ok {
+				continue
+			}
+			for key, val := range assoc {
+				if _, exists := member[key]; !exists {
+					member[key] = val
+				}
+			}
+			members = append(members, member)
+		}
+		writePage(w, r, members)
+	}
+}
+
+func (s *Server) updateAssociation(collection, parentField, memberField string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req, err := readParams(r)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		delete(req, parentField)
+		delete(req, memberField)
+		if !s.store.update(collection, fmt.Sprintf("%s:%s", params["id"], params["member_id"]), req) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", memberField))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) dissociate(collection, parentField, memberField string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if !s.store.delete(collection, fmt.Sprintf("%s:%s", params["id"], params["member_id"])) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", memberField))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// issueToken signs and stores a bearer token for the given subject
+func (s *Server) issueToken(subject string, ttl time.Duration) (map[string]interface{}, error) {
+	token, claims, err := s.signToken(subject, ttl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.insert("tokens", map[string]interface{}{
+		"id":    claims["jti"],
+		"token": token,
+	}), nil
+}
+
+// issueAccessToken signs and stores an access token, without a refresh token, for the given subject
+func (s *Server) issueAccessToken(subject, scope string, fields map[string]interface{}) (map[string]interface{}, error) {
+	accessToken, claims, err := s.signToken(subject, accessTokenTTL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tkn := map[string]interface{}{
+		"id":           claims["jti"],
+		"access_token": accessToken,
+		"expires_in":   int64(accessTokenTTL / time.Second),
+	}
+	if scope != "" {
+		tkn["scope"] = scope
+	}
+	for key, val := range fields {
+		tkn[key] = val
+	}
+
+	return s.store.insert("tokens", tkn), nil
+}
+
+// issueRefreshableToken signs and stores an access token and a refresh token for the given subject
+func (s *Server) issueRefreshableToken(subject string, fields map[string]interface{}) (map[string]interface{}, error) {
+	accessToken, claims, err := s.signToken(subject, accessTokenTTL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshClaims, err := s.signToken(subject, refreshTokenTTL, map[string]interface{}{
+		"scope": scopeOfflineAccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.refreshIDs[refreshClaims["jti"].(string)] = true
+	s.mutex.Unlock()
+
+	tkn := map[string]interface{}{
+		"id":            claims["jti"],
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+		"expires_in":    int64(accessTokenTTL / time.Second),
+		"scope":         scopeOfflineAccess,
+	}
+	for key, val := range fields {
+		tkn[key] = val
+	}
+
+	return s.store.insert("tokens", tkn), nil
+}
+
+// signToken returns an RS256 JWT for the given subject, along with its claims; the prvd claims
+// carry the id of an application, organization or user subject
+func (s *Server) signToken(subject string, ttl time.Duration, extra map[string]interface{}) (string, map[string]interface{}, error) {
+	now := time.Now()
+	claims := map[string]interface{}{
+		"aud": s.Ident.URL,
+		"exp": now.Add(ttl).Unix(),
+		"iat": now.Unix(),
+		"iss": fakeIssuer,
+		"jti": newID(),
+		"sub": subject,
+	}
+
+	prvd := map[string]interface{}{}
+	if segments := strings.SplitN(subject, ":", 2); len(segments) == 2 && segments[0] != "token" {
+		prvd[fmt.Sprintf("%s_id", segments[0])] = segments[1]
+	}
+	claims["prvd"] = prvd
+
+	for key, val := range extra {
+		claims[key] = val
+	}
+
+	header, err := json.Marshal(map[string]interface{}{
+		"alg": "RS256",
+		"kid": s.keyID,
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	signingInput := fmt.Sprintf("%s.%s", base64.RawURLEncoding.EncodeToString(header), base64.RawURLEncoding.EncodeToString(payload))
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(nil, s.signingKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("%s.%s", signingInput, base64.RawURLEncoding.EncodeToString(sig)), claims, nil
+}
+
+// verifyToken verifies the signature and expiration of the given token and returns its claims
+func (s *Server) verifyToken(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%s.%s", parts[0], parts[1])))
+	err = rsa.VerifyPKCS1v15(&s.signingKey.PublicKey, crypto.SHA256, digest[:], sig)
+	if err != nil {
+		return nil, errors.New("invalid token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token claims")
+	}
+
+	claims := map[string]interface{}{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, errors.New("malformed token claims")
+	}
+
+	exp, _ := claims["exp"].(float64)
+	if time.Now().Unix() >= int64(exp) {
+		return nil, errors.New("token expired")
+	}
+
+	s.mutex.Lock()
+	revoked := s.revoked[fmt.Sprintf("%v", claims["jti"])]
+	s.mutex.Unlock()
+	if revoked {
+		return nil, errors.New("token revoked")
+	}
+
+	return claims, nil
+}
+
+// bearerClaims returns the claims of the valid bearer token authorizing the given request
+func (s *Server) bearerClaims(r *http.Request) (map[string]interface{}, bool) {
+	claims, err := s.verifyToken(bearerToken(r))
+	if err != nil || claims["scope"] == scopeOfflineAccess {
+		return nil, false
+	}
+	return claims, true
+}
+
+func bearerToken(r *http.Request) string {
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) < 7 || !strings.EqualFold(authorization[:7], "bearer ") {
+		return ""
+	}
+	return authorization[7:]
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	_, ok := s.bearerClaims(r)
+	return ok
+}
@@ -0,0 +1,227 @@
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (s *Server) nchainRouter() http.Handler {
+	rt := s.newRouter()
+
+	rt.api("GET", "accounts", s.list("accounts", ""))
+	rt.api("POST", "accounts", s.createAccount)
+	rt.api("GET", "accounts/:id", s.get("accounts", "id"))
+	rt.api("GET", "accounts/:id/balances/:token_id", s.accountBalance)
+
+	rt.api("GET", "bridges", s.list("bridges", ""))
+	rt.api("POST", "bridges", s.create("bridges", http.StatusCreated, nil))
+	rt.api("GET", "bridges/:id", s.get("bridges", "id"))
+
+	rt.api("GET", "connectors", s.list("connectors", ""))
+	rt.api("POST", "connectors", s.create("connectors", http.StatusCreated, nil))
+	rt.api("GET", "connectors/:id", s.get("connectors", "id"))
+	rt.api("DELETE", "connectors/:id", s.delete("connectors", "id"))
+
+	rt.api("GET", "contracts", s.list("contracts", ""))
+	rt.api("POST", "contracts", s.create("contracts", http.StatusCreated, nil))
+	rt.api("GET", "contracts/:id", s.get("contracts", "id"))
+	rt.api("POST", "contracts/:id/execute", s.executeContract)
+	rt.api("POST", "contracts/:id/subscriptions", s.vendSubscriptionToken)
+	rt.public("POST", fmt.Sprintf("%s/public/contracts", apiPath), s.create("contracts", http.StatusCreated, nil))
+
+	rt.api("GET", "networks", s.list("networks", ""))
+	rt.api("POST", "networks", s.create("networks", http.StatusCreated, nil))
+	rt.api("GET", "networks/:id", s.get("networks", "id"))
+	rt.api("PUT", "networks/:id", s.update("networks", "id", http.StatusNoContent))
+	rt.api("GET", "networks/:id/accounts", s.list("accounts", "network_id"))
+	rt.api("GET", "networks/:id/blocks", s.list("blocks", "network_id"))
+	rt.api("GET", "networks/:id/bridges", s.list("bridges", "network_id"))
+	rt.api("GET", "networks/:id/connectors", s.list("connectors", "network_id"))
+	rt.api("GET", "networks/:id/contracts", s.list("contracts", "network_id"))
+	rt.api("GET", "networks/:id/contracts/:contract_id", s.get("contracts", "contract_id"))
+	rt.api("GET", "networks/:id/oracles", s.list("oracles", "network_id"))
+	rt.api("GET", "networks/:id/tokens", s.list("token_contracts", "network_id"))
+	rt.api("GET", "networks/:id/transactions", s.list("transactions", "network_id"))
+	rt.api("GET", "networks/:id/transactions/:tx_id", s.get("transactions", "tx_id"))
+	rt.api("GET", "networks/:id/status", s.networkStatus)
+
+	rt.api("GET", "oracles", s.list("oracles", ""))
+	rt.api("POST", "oracles", s.create("oracles", http.StatusCreated, nil))
+	rt.api("GET", "oracles/:id", s.get("oracles", "id"))
+
+	rt.api("GET", "tokens", s.list("token_contracts", ""))
+	rt.api("POST", "tokens", s.create("token_contracts", http.StatusCreated, nil))
+	rt.api("GET", "tokens/:id", s.get("token_contracts", "id"))
+
+	rt.api("GET", "transactions", s.list("transactions", ""))
+	rt.api("POST", "transactions", s.createTransaction)
+	rt.api("GET", "transactions/:id", s.get("transactions", "id"))
+
+	rt.api("GET", "wallets", s.list("wallets", ""))
+	rt.api("POST", "wallets", s.create("wallets", http.StatusCreated, nil))
+	rt.api("GET", "wallets/:id", s.get("wallets", "id"))
+	rt.api("GET", "wallets/:id/accounts", s.list("accounts", "wallet_id"))
+
+	return rt
+}
+
+// createAccount creates an account having a random address; the address is not derived from
+// a real keypair and cannot be used to sign transactions
+func (s *Server) createAccount(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if stringParam(req, "address") == "" {
+		address, err := randomHex(20)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		req["address"] = address
+	}
+
+	writeJSON(w, http.StatusCreated, s.store.insert("accounts", req))
+}
+
+func (s *Server) accountBalance(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.store.get("accounts", params["id"]); !ok {
+		writeError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"account_id": params["id"],
+		"token_id":   params["token_id"],
+		"balance":    0,
+	})
+}
+
+// createTransaction records a transaction having a random hash and appends a block containing
+// it to the transaction's network
+func (s *Server) createTransaction(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	tx, err := s.insertTransaction(req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tx)
+}
+
+func (s *Server) insertTransaction(tx map[string]interface{}) (map[string]interface{}, error) {
+	hash, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	networkID := stringParam(tx, "network_id")
+	block := len(s.store.find("blocks", func(blk map[string]interface{}) bool {
+		return blk["network_id"] == networkID
+	}))
+
+	tx["hash"] = hash
+	tx["block"] = block
+	tx["status"] = "success"
+	tx = s.store.insert("transactions", tx)
+
+	s.store.insert("blocks", map[string]interface{}{
+		"network_id":   networkID,
+		"block":        block,
+		"transactions": []string{hash},
+		"timestamp":    time.Now().Unix(),
+	})
+
+	return tx, nil
+}
+
+// executeContract records a transaction for the contract execution and responds with its
+// reference, as the API does for asynchronous executions
+func (s *Server) executeContract(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	contract, ok := s.store.get("contracts", params["id"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "contract not found")
+		return
+	}
+
+	req["contract_id"] = contract["id"]
+	req["network_id"] = contract["network_id"]
+	req["to"] = contract["address"]
+
+	tx, err := s.insertTransaction(req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"confidence": 1,
+		"ref":        tx["id"],
+	})
+}
+
+func (s *Server) vendSubscriptionToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.store.get("contracts", params["id"]); !ok {
+		writeError(w, http.StatusNotFound, "contract not found")
+		return
+	}
+
+	tkn, err := s.issueToken(fmt.Sprintf("contract:%s", params["id"]), accessTokenTTL)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tkn)
+}
+
+func (s *Server) networkStatus(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	network, ok := s.store.get("networks", params["id"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "network not found")
+		return
+	}
+
+	blocks := s.store.find("blocks", func(blk map[string]interface{}) bool {
+		return blk["network_id"] == params["id"]
+	})
+
+	status := map[string]interface{}{
+		"block":      len(blocks),
+		"peer_count": 0,
+		"state":      "synced",
+		"syncing":    false,
+	}
+	if config, ok := network["config"].(map[string]interface{}); ok {
+		if chainID, ok := config["chain_id"]; ok {
+			status["chain_id"] = fmt.Sprintf("%v", chainID)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+func randomHex(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("0x%s", hex.EncodeToString(raw)), nil
+}
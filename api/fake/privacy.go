@@ -0,0 +1,173 @@
+package fake
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) privacyRouter() http.Handler {
+	rt := s.newRouter()
+
+	rt.api("GET", "circuits", s.list("circuits", ""))
+	rt.api("POST", "circuits", s.createCircuit)
+	rt.api("GET", "circuits/:id", s.get("circuits", "id"))
+	rt.api("POST", "circuits/:id/prove", s.prove)
+	rt.api("POST", "circuits/:id/verify", s.verifyProof)
+	rt.api("GET", "circuits/:id/notes/:index", s.noteValue)
+	rt.api("GET", "circuits/:id/nullifiers/:key", s.nullifierValue)
+
+	return rt
+}
+
+// createCircuit creates a circuit along with the secret key used to generate its proofs
+func (s *Server) createCircuit(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	req["status"] = "provisioned"
+	req["note_store_id"] = newID()
+	req["nullifier_store_id"] = newID()
+	circuit := s.store.insert("circuits", req)
+
+	s.mutex.Lock()
+	s.circuitKeys[circuit["id"].(string)] = key
+	s.mutex.Unlock()
+
+	writeJSON(w, http.StatusCreated, circuit)
+}
+
+// prove responds with a proof of the witness request param; proofs are HMAC-SHA256 digests of
+// the witness under the circuit's key, so they verify only against the same circuit and witness.
+// Each proof appends a note to the circuit's note store and records its nullifier.
+func (s *Server) prove(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.circuitRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	witness, err := json.Marshal(req["witness"])
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	proof := hex.EncodeToString(circuitProof(key, witness))
+	note := fmt.Sprintf("%x", sha256.Sum256(witness))
+	nullifier := fmt.Sprintf("%x", sha256.Sum256([]byte(proof)))
+
+	// the index of the note is assigned under the lock so concurrent proofs append distinct notes
+	s.mutex.Lock()
+	index := len(s.store.find("notes", func(n map[string]interface{}) bool {
+		return n["circuit_id"] == params["id"]
+	}))
+	s.store.insert("notes", map[string]interface{}{
+		"id":         fmt.Sprintf("%s:%d", params["id"], index),
+		"circuit_id": params["id"],
+		"value":      note,
+	})
+	s.mutex.Unlock()
+	s.store.insert("nullifiers", map[string]interface{}{
+		"id":         fmt.Sprintf("%s:%s", params["id"], nullifier),
+		"circuit_id": params["id"],
+		"value":      proof,
+	})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"proof": proof,
+	})
+}
+
+func (s *Server) verifyProof(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.circuitRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	witness, err := json.Marshal(req["witness"])
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	proof, err := hex.DecodeString(stringParam(req, "proof"))
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"result": err == nil && hmac.Equal(proof, circuitProof(key, witness)),
+	})
+}
+
+func (s *Server) noteValue(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	index, err := strconv.ParseUint(params["index"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "invalid note index")
+		return
+	}
+
+	note, ok := s.store.get("notes", fmt.Sprintf("%s:%d", params["id"], index))
+	if !ok {
+		writeError(w, http.StatusNotFound, "note not found")
+		return
+	}
+
+	length := len(s.store.find("notes", func(n map[string]interface{}) bool {
+		return n["circuit_id"] == params["id"]
+	}))
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"length": length,
+		"value":  note["value"],
+	})
+}
+
+func (s *Server) nullifierValue(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	nullifier, ok := s.store.get("nullifiers", fmt.Sprintf("%s:%s", params["id"], params["key"]))
+	if !ok {
+		writeError(w, http.StatusNotFound, "nullifier not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"nullifier_key": params["key"],
+		"value":         nullifier["value"],
+	})
+}
+
+// circuitRequest reads the request params and resolves the key of the circuit identified by
+// the id path parameter
+func (s *Server) circuitRequest(w http.ResponseWriter, r *http.Request, params map[string]string) (map[string]interface{}, []byte, bool) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return nil, nil, false
+	}
+
+	s.mutex.Lock()
+	key, ok := s.circuitKeys[params["id"]]
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "circuit not found")
+		return nil, nil, false
+	}
+
+	return req, key, true
+}
+
+func circuitProof(key, witness []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(witness)
+	return mac.Sum(nil)
+}
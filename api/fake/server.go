@@ -0,0 +1,520 @@
+// Package fake provides in-process fakes of the Provide APIs for hermetic integration tests.
+//
+// NewServer starts one httptest.Server per service (ident, vault, nchain, privacy and baseline)
+// which serves the routes used by the api/* clients from in-memory state. Sign, verify, encrypt
+// and decrypt operations use real keys from the standard library, and bearer tokens are RS256
+// JWTs issued by the fake ident server and published at its /.well-known/keys endpoint.
+//
+//	srv := fake.NewServer()
+//	defer srv.Close()
+//	defer srv.Setenv()()
+//
+//	token := srv.Token()
+//	vlt, err := vault.CreateVault(token, map[string]interface{}{"name": "test"})
+package fake
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/kthomas/go.uuid"
+	"github.com/provideplatform/provide-go/api"
+)
+
+const apiPath = "api/v1"
+const defaultResultsPerPage = 25
+const totalResultsCountHeader = "X-Total-Results-Count"
+
+// Server is a set of fake Provide API servers sharing a single in-memory state
+type Server struct {
+	Baseline *httptest.Server
+	Ident    *httptest.Server
+	NChain   *httptest.Server
+	Privacy  *httptest.Server
+	Vault    *httptest.Server
+
+	store *store
+
+	mutex       sync.Mutex
+	signingKey  *rsa.PrivateKey
+	keyID       string
+	revoked     map[string]bool
+	refreshIDs  map[string]bool
+	authCodes   map[string]*authorizationCode
+	passwords   map[string]string
+	keys        map[string]*keyMaterial
+	circuitKeys map[string][]byte
+	sealed      bool
+	unsealerKey string
+}
+
+// NewServer starts and returns a new *Server; callers should Close it when done
+func NewServer() *Server {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(fmt.Sprintf("fake: failed to generate token signing key; %s", err.Error()))
+	}
+
+	s := &Server{
+		store:       newStore(),
+		signingKey:  signingKey,
+		keyID:       newID(),
+		revoked:     map[string]bool{},
+		refreshIDs:  map[string]bool{},
+		authCodes:   map[string]*authorizationCode{},
+		passwords:   map[string]string{},
+		keys:        map[string]*keyMaterial{},
+		circuitKeys: map[string][]byte{},
+	}
+
+	s.Baseline = httptest.NewServer(s.baselineRouter())
+	s.Ident = httptest.NewServer(s.identRouter())
+	s.NChain = httptest.NewServer(s.nchainRouter())
+	s.Privacy = httptest.NewServer(s.privacyRouter())
+	s.Vault = httptest.NewServer(s.vaultRouter())
+
+	return s
+}
+
+// Close shuts down all of the fake servers
+func (s *Server) Close() {
+	for _, srv := range s.servers() {
+		srv.Close()
+	}
+}
+
+// Setenv points the *_API_HOST, *_API_PATH and *_API_SCHEME environment variables of each
+// service at the fake servers; the returned func restores the previous environment
+func (s *Server) Setenv() func() {
+	previous := map[string]*string{}
+
+	for prefix, srv := range s.servers() {
+		u, _ := url.Parse(srv.URL)
+		env := map[string]string{
+			fmt.Sprintf("%s_API_HOST", prefix):   u.Host,
+			fmt.Sprintf("%s_API_PATH", prefix):   apiPath,
+			fmt.Sprintf("%s_API_SCHEME", prefix): u.Scheme,
+		}
+
+		for key, val := range env {
+			if prev, ok := os.LookupEnv(key); ok {
+				previous[key] = &prev
+			} else {
+				previous[key] = nil
+			}
+			os.Setenv(key, val)
+		}
+	}
+
+	return func() {
+		for key, val := range previous {
+			if val == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *val)
+			}
+		}
+	}
+}
+
+// Config returns an *api.Config for the named service, i.e. BASELINE, IDENT, NCHAIN, PRIVACY
+// or VAULT, which targets the corresponding fake server; the given token, if any, is used as
+// the bearer token
+func (s *Server) Config(name string, token *string) *api.Config {
+	srv, ok := s.servers()[strings.ToUpper(name)]
+	if !ok {
+		return nil
+	}
+
+	u, _ := url.Parse(srv.URL)
+	return &api.Config{
+		Host:   u.Host,
+		Path:   apiPath,
+		Scheme: u.Scheme,
+		Token:  token,
+	}
+}
+
+// Token issues a bearer token for a new, anonymous subject; it is accepted by all of the
+// fake servers until it expires or is revoked
+func (s *Server) Token() string {
+	tkn, err := s.issueToken(fmt.Sprintf("token:%s", newID()), time.Hour)
+	if err != nil {
+		panic(fmt.Sprintf("fake: failed to issue token; %s", err.Error()))
+	}
+	return tkn["token"].(string)
+}
+
+func (s *Server) servers() map[string]*httptest.Server {
+	return map[string]*httptest.Server{
+		"BASELINE": s.Baseline,
+		"IDENT":    s.Ident,
+		"NCHAIN":   s.NChain,
+		"PRIVACY":  s.Privacy,
+		"VAULT":    s.Vault,
+	}
+}
+
+// handlerFunc handles a routed request; params contains the named path parameters
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	public   bool
+	handler  handlerFunc
+}
+
+// router matches requests against patterns such as api/v1/vaults/:id/keys
+type router struct {
+	server *Server
+	routes []*route
+}
+
+func (s *Server) newRouter() *router {
+	return &router{server: s}
+}
+
+// api registers an authorized route relative to the api path
+func (rt *router) api(method, pattern string, handler handlerFunc) {
+	rt.handle(method, fmt.Sprintf("%s/%s", apiPath, pattern), false, handler)
+}
+
+// public registers a route, relative to the server root, which does not require authorization
+func (rt *router) public(method, pattern string, handler handlerFunc) {
+	rt.handle(method, pattern, true, handler)
+}
+
+func (rt *router) handle(method, pattern string, public bool, handler handlerFunc) {
+	rt.routes = append(rt.routes, &route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		public:   public,
+		handler:  handler,
+	})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	methodNotAllowed := false
+
+	for _, route := range rt.routes {
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+
+		if route.method != r.Method {
+			methodNotAllowed = true
+			continue
+		}
+
+		if !route.public && !rt.server.authorized(r) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		route.handler(w, r, params)
+		return
+	}
+
+	if methodNotAllowed {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+func (r *route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, ":") {
+			params[segment[1:]] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// store is an in-memory set of named collections of JSON objects keyed by id
+type store struct {
+	mutex       sync.Mutex
+	collections map[string]map[string]map[string]interface{}
+	order       map[string][]string
+}
+
+func newStore() *store {
+	return &store{
+		collections: map[string]map[string]map[string]interface{}{},
+		order:       map[string][]string{},
+	}
+}
+
+// insert adds the given object to the named collection, assigning its id and created_at
+// fields unless present, and returns a copy of the stored object
+func (s *store) insert(collection string, obj map[string]interface{}) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	obj = copyObject(obj)
+	id, ok := obj["id"].(string)
+	if !ok || id == "" {
+		id = newID()
+		obj["id"] = id
+	}
+	if _, ok := obj["created_at"]; !ok {
+		obj["created_at"] = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	if s.collections[collection] == nil {
+		s.collections[collection] = map[string]map[string]interface{}{}
+	}
+	if _, exists := s.collections[collection][id]; !exists {
+		s.order[collection] = append(s.order[collection], id)
+	}
+	s.collections[collection][id] = obj
+
+	return copyObject(obj)
+}
+
+func (s *store) get(collection, id string) (map[string]interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	obj, ok := s.collections[collection][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// find returns the objects in the named collection, in insertion order, for which the given
+// filter, if any, returns true
+func (s *store) find(collection string, filter func(map[string]interface{}) bool) []map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	objs := make([]map[string]interface{}, 0)
+	for _, id := range s.order[collection] {
+		obj := s.collections[collection][id]
+		if filter == nil || filter(obj) {
+			objs = append(objs, copyObject(obj))
+		}
+	}
+	return objs
+}
+
+// update merges the given params into the object; the id and created_at fields are immutable
+func (s *store) update(collection, id string, params map[string]interface{}) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	obj, ok := s.collections[collection][id]
+	if !ok {
+		return false
+	}
+
+	for key, val := range params {
+		if key == "id" || key == "created_at" {
+			continue
+		}
+		obj[key] = val
+	}
+	return true
+}
+
+func (s *store) delete(collection, id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.collections[collection][id]; !ok {
+		return false
+	}
+
+	delete(s.collections[collection], id)
+	for i, oid := range s.order[collection] {
+		if oid == id {
+			s.order[collection] = append(s.order[collection][:i], s.order[collection][i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// create returns a handler which inserts the request params into the named collection; the
+// value of each named path parameter is stored in the corresponding field
+func (s *Server) create(collection string, status int, fields map[string]string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		obj, err := readParams(r)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		for param, field := range fields {
+			obj[field] = params[param]
+		}
+
+		writeJSON(w, status, s.store.insert(collection, obj))
+	}
+}
+
+// list returns a handler which writes a page of the named collection; when field is non-empty,
+// only objects whose field matches the id path parameter are listed
+func (s *Server) list(collection, field string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		var filter func(map[string]interface{}) bool
+		if field != "" {
+			filter = func(obj map[string]interface{}) bool {
+				return obj[field] == params["id"]
+			}
+		}
+		writePage(w, r, s.store.find(collection, filter))
+	}
+}
+
+// get returns a handler which writes the object identified by the given path parameter
+func (s *Server) get(collection, param string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		obj, ok := s.store.get(collection, params[param])
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", collection))
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	}
+}
+
+// update returns a handler which merges the request params into the object identified by the
+// given path parameter
+func (s *Server) update(collection, param string, status int) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		obj, err := readParams(r)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		if !s.store.update(collection, params[param], obj) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", collection))
+			return
+		}
+
+		if status == http.StatusNoContent {
+			w.WriteHeader(status)
+			return
+		}
+
+		updated, _ := s.store.get(collection, params[param])
+		writeJSON(w, status, updated)
+	}
+}
+
+// delete returns a handler which deletes the object identified by the given path parameter
+func (s *Server) delete(collection, param string) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if !s.store.delete(collection, params[param]) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", collection))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func readParams(r *http.Request) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	if r.Body == nil || r.ContentLength == 0 {
+		return params, nil
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request params; %s", err.Error())
+	}
+	return params, nil
+}
+
+// writePage writes the page of objs selected by the page and rpp query parameters along with
+// the X-Total-Results-Count header
+func writePage(w http.ResponseWriter, r *http.Request, objs []map[string]interface{}) {
+	page := objs
+	query := r.URL.Query()
+	if query.Get("page") != "" || query.Get("rpp") != "" {
+		pg, err := strconv.Atoi(query.Get("page"))
+		if err != nil || pg < 1 {
+			pg = 1
+		}
+		rpp, err := strconv.Atoi(query.Get("rpp"))
+		if err != nil || rpp < 1 {
+			rpp = defaultResultsPerPage
+		}
+
+		start := (pg - 1) * rpp
+		if start > len(objs) {
+			start = len(objs)
+		}
+		end := start + rpp
+		if end > len(objs) {
+			end = len(objs)
+		}
+		page = objs[start:end]
+	}
+
+	w.Header().Set(totalResultsCountHeader, strconv.Itoa(len(objs)))
+	writeJSON(w, http.StatusOK, page)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []*api.Error{
+			{
+				Message: &msg,
+				Status:  &status,
+			},
+		},
+	})
+}
+
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	cpy := make(map[string]interface{}, len(obj))
+	for key, val := range obj {
+		cpy[key] = val
+	}
+	return cpy
+}
+
+func stringParam(params map[string]interface{}, key string) string {
+	val, _ := params[key].(string)
+	return val
+}
+
+func newID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		panic(fmt.Sprintf("fake: failed to generate uuid; %s", err.Error()))
+	}
+	return id.String()
+}
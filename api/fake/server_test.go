@@ -0,0 +1,210 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/api/fake"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/api/privacy"
+	"github.com/provideplatform/provide-go/api/vault"
+)
+
+func TestIdentAuthenticateAndRefresh(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	_, err := ident.CreateUser("", map[string]interface{}{
+		"email":    "user@example.com",
+		"password": "s3cr3t",
+	})
+	if err != nil {
+		t.Fatalf("failed to create user; %s", err.Error())
+	}
+
+	_, err = ident.Authenticate("user@example.com", "wrong")
+	if !errors.Is(err, api.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error for invalid password; got %v", err)
+	}
+
+	resp, err := ident.Authenticate("user@example.com", "s3cr3t")
+	if err != nil {
+		t.Fatalf("failed to authenticate; %s", err.Error())
+	}
+	if resp.Token.RefreshToken == nil {
+		t.Fatal("expected refresh token for offline_access scope")
+	}
+
+	ts := ident.NewRefreshTokenSource(*resp.Token.RefreshToken, nil)
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("failed to refresh access token; %s", err.Error())
+	}
+
+	_, err = vault.CreateVault(token, map[string]interface{}{"name": "test"})
+	if err != nil {
+		t.Fatalf("expected refreshed access token to be authorized; %s", err.Error())
+	}
+
+	ts.Invalidate(token)
+	if _, err = ts.Token(context.Background()); err != nil {
+		t.Fatalf("failed to refresh access token using rotated refresh token; %s", err.Error())
+	}
+	if ts.RefreshToken() == *resp.Token.RefreshToken {
+		t.Fatal("expected token source to adopt rotated refresh token")
+	}
+
+	_, err = vault.CreateVault("invalid", map[string]interface{}{"name": "test"})
+	if !errors.Is(err, api.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error for invalid token; got %v", err)
+	}
+}
+
+func TestVaultSignVerifyEncryptDecrypt(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	token := srv.Token()
+	vlt, err := vault.CreateVault(token, map[string]interface{}{"name": "test"})
+	if err != nil {
+		t.Fatalf("failed to create vault; %s", err.Error())
+	}
+	vaultID := vlt.ID.String()
+
+	signer, err := vault.CreateKey(token, vaultID, map[string]interface{}{
+		"name": "signer",
+		"spec": vault.KeySpecECCEd25519,
+	})
+	if err != nil {
+		t.Fatalf("failed to create signing key; %s", err.Error())
+	}
+
+	sig, err := vault.SignMessage(token, vaultID, signer.ID.String(), "hello", nil)
+	if err != nil {
+		t.Fatalf("failed to sign message; %s", err.Error())
+	}
+
+	for msg, expected := range map[string]bool{"hello": true, "goodbye": false} {
+		verification, err := vault.VerifySignature(token, vaultID, signer.ID.String(), msg, *sig.Signature, nil)
+		if err != nil {
+			t.Fatalf("failed to verify signature; %s", err.Error())
+		}
+		if verification.Verified != expected {
+			t.Errorf("expected verification of %q to be %v", msg, expected)
+		}
+	}
+
+	aes, err := vault.CreateKey(token, vaultID, map[string]interface{}{
+		"name": "aes",
+		"spec": vault.KeySpecAES256GCM,
+	})
+	if err != nil {
+		t.Fatalf("failed to create symmetric key; %s", err.Error())
+	}
+
+	encrypted, err := vault.Encrypt(token, vaultID, aes.ID.String(), "plaintext")
+	if err != nil {
+		t.Fatalf("failed to encrypt; %s", err.Error())
+	}
+	if encrypted.Data == "plaintext" {
+		t.Fatal("expected ciphertext to differ from plaintext")
+	}
+
+	decrypted, err := vault.Decrypt(token, vaultID, aes.ID.String(), map[string]interface{}{
+		"data": encrypted.Data,
+	})
+	if err != nil {
+		t.Fatalf("failed to decrypt; %s", err.Error())
+	}
+	if decrypted.Data != "plaintext" {
+		t.Errorf("expected decrypted plaintext; got %s", decrypted.Data)
+	}
+
+	keys := make([]*vault.Key, 0)
+	err = vault.ListKeysPager(token, vaultID, map[string]interface{}{"rpp": 1}).All(context.Background(), &keys)
+	if err != nil {
+		t.Fatalf("failed to page keys; %s", err.Error())
+	}
+	if len(keys) != 2 {
+		t.Errorf("expected 2 keys; got %d", len(keys))
+	}
+}
+
+func TestPrivacyProveVerify(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	token := srv.Token()
+	circuit, err := privacy.CreateCircuit(token, map[string]interface{}{"name": "test"})
+	if err != nil {
+		t.Fatalf("failed to create circuit; %s", err.Error())
+	}
+	circuitID := circuit.ID.String()
+
+	witness := map[string]interface{}{"x": "3", "y": "35"}
+	proof, err := privacy.Prove(token, circuitID, map[string]interface{}{"witness": witness})
+	if err != nil {
+		t.Fatalf("failed to generate proof; %s", err.Error())
+	}
+
+	verification, err := privacy.Verify(token, circuitID, map[string]interface{}{
+		"proof":   *proof.Proof,
+		"witness": witness,
+	})
+	if err != nil {
+		t.Fatalf("failed to verify proof; %s", err.Error())
+	}
+	if !verification.Result {
+		t.Error("expected proof to verify")
+	}
+
+	note, err := privacy.GetNoteValue(token, circuitID, 0)
+	if err != nil {
+		t.Fatalf("failed to fetch note; %s", err.Error())
+	}
+	if note.Value == nil || *note.Length != 1 {
+		t.Error("expected proof to append a note")
+	}
+}
+
+func TestPrivacyConcurrentProofsAppendDistinctNotes(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	token := srv.Token()
+	circuit, err := privacy.CreateCircuit(token, map[string]interface{}{"name": "test"})
+	if err != nil {
+		t.Fatalf("failed to create circuit; %s", err.Error())
+	}
+	circuitID := circuit.ID.String()
+
+	const proofs = 100
+	var wg sync.WaitGroup
+	for i := 0; i < proofs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			witness := map[string]interface{}{"x": fmt.Sprintf("%d", i)}
+			if _, err := privacy.Prove(token, circuitID, map[string]interface{}{"witness": witness}); err != nil {
+				t.Errorf("failed to generate proof; %s", err.Error())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	note, err := privacy.GetNoteValue(token, circuitID, proofs-1)
+	if err != nil {
+		t.Fatalf("failed to fetch note; %s", err.Error())
+	}
+	if note.Length == nil || *note.Length != proofs {
+		t.Errorf("expected %d notes; got %v", proofs, note.Length)
+	}
+}
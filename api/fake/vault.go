@@ -0,0 +1,496 @@
+package fake
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/provideplatform/provide-go/api/vault"
+)
+
+// keyMaterial is the private material of a fake vault key
+type keyMaterial struct {
+	spec      string
+	ed25519   ed25519.PrivateKey
+	rsa       *rsa.PrivateKey
+	symmetric []byte
+}
+
+func (s *Server) vaultRouter() http.Handler {
+	rt := s.newRouter()
+
+	rt.api("GET", "vaults", s.list("vaults", ""))
+	rt.api("POST", "vaults", s.create("vaults", http.StatusCreated, nil))
+
+	rt.api("GET", "vaults/:id/keys", s.list("keys", "vault_id"))
+	rt.api("POST", "vaults/:id/keys", s.createKey)
+	rt.api("GET", "vaults/:id/keys/:key_id", s.get("keys", "key_id"))
+	rt.api("DELETE", "vaults/:id/keys/:key_id", s.deleteKey)
+	rt.api("POST", "vaults/:id/keys/:key_id/derive", s.deriveKey)
+	rt.api("POST", "vaults/:id/keys/:key_id/sign", s.sign)
+	rt.api("POST", "vaults/:id/keys/:key_id/verify", s.verify)
+	rt.api("POST", "vaults/:id/keys/:key_id/encrypt", s.encrypt)
+	rt.api("POST", "vaults/:id/keys/:key_id/decrypt", s.decrypt)
+
+	rt.api("GET", "vaults/:id/secrets", s.listSecrets)
+	rt.api("POST", "vaults/:id/secrets", s.createSecret)
+	rt.api("GET", "vaults/:id/secrets/:secret_id", s.get("secrets", "secret_id"))
+	rt.api("DELETE", "vaults/:id/secrets/:secret_id", s.delete("secrets", "secret_id"))
+
+	rt.api("POST", "unsealerkey", s.generateUnsealerKey)
+	rt.api("POST", "seal", s.seal)
+	rt.api("POST", "unseal", s.unseal)
+	rt.api("POST", "verify", s.verifyDetached)
+	rt.api("POST", "bls/aggregate", notImplemented)
+	rt.api("POST", "bls/verify", notImplemented)
+
+	return rt
+}
+
+// notImplemented handles routes which require cryptography unavailable in the standard library
+func notImplemented(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	writeError(w, http.StatusNotImplemented, "not implemented by the fake vault")
+}
+
+// createKey generates a key for one of the Ed25519, RSA-2048, RSA-3072, RSA-4096 or AES-256-GCM
+// specs; other specs are rejected since they have no standard library implementation
+func (s *Server) createKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if _, ok := s.store.get("vaults", params["id"]); !ok {
+		writeError(w, http.StatusNotFound, "vault not found")
+		return
+	}
+
+	key, err := generateKey(stringParam(req, "spec"))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	req["vault_id"] = params["id"]
+	writeJSON(w, http.StatusCreated, s.insertKey(req, key))
+}
+
+func (s *Server) insertKey(obj map[string]interface{}, key *keyMaterial) map[string]interface{} {
+	obj["spec"] = key.spec
+	if key.symmetric != nil {
+		obj["type"] = vault.KeyTypeSymmetric
+		obj["usage"] = vault.KeyUsageEncryptDecrypt
+	} else {
+		obj["type"] = vault.KeyTypeAsymmetric
+		if stringParam(obj, "usage") == "" {
+			obj["usage"] = vault.KeyUsageSignVerify
+		}
+		obj["public_key"] = key.publicKey()
+	}
+
+	obj = s.store.insert("keys", obj)
+
+	s.mutex.Lock()
+	s.keys[obj["id"].(string)] = key
+	s.mutex.Unlock()
+
+	return obj
+}
+
+func (s *Server) deleteKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if !s.store.delete("keys", params["key_id"]) {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+
+	s.mutex.Lock()
+	delete(s.keys, params["key_id"])
+	s.mutex.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deriveKey derives a new AES-256-GCM key from a symmetric key using HMAC-SHA256 over the
+// nonce and context request params
+func (s *Server) deriveKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.keyRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	if key.symmetric == nil {
+		writeError(w, http.StatusUnprocessableEntity, "key derivation requires a symmetric key")
+		return
+	}
+
+	mac := hmac.New(sha256.New, key.symmetric)
+	mac.Write([]byte(fmt.Sprintf("%v", req["nonce"])))
+	mac.Write([]byte(fmt.Sprintf("%v", req["context"])))
+
+	derived := map[string]interface{}{
+		"vault_id":    params["id"],
+		"name":        req["name"],
+		"description": req["description"],
+	}
+	writeJSON(w, http.StatusCreated, s.insertKey(derived, &keyMaterial{
+		spec:      vault.KeySpecAES256GCM,
+		symmetric: mac.Sum(nil),
+	}))
+}
+
+// sign writes the hex-encoded signature of the message request param
+func (s *Server) sign(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.keyRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	var sig []byte
+	var err error
+	msg := []byte(stringParam(req, "message"))
+
+	switch {
+	case key.ed25519 != nil:
+		sig = ed25519.Sign(key.ed25519, msg)
+	case key.rsa != nil:
+		digest := sha256.Sum256(msg)
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key.rsa, crypto.SHA256, digest[:])
+	default:
+		err = errors.New("signing requires an asymmetric key")
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	signature := hex.EncodeToString(sig)
+	writeJSON(w, http.StatusCreated, &vault.SignResponse{
+		Signature: &signature,
+	})
+}
+
+func (s *Server) verify(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.keyRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	var pub crypto.PublicKey
+	switch {
+	case key.ed25519 != nil:
+		pub = key.ed25519.Public()
+	case key.rsa != nil:
+		pub = &key.rsa.PublicKey
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "verification requires an asymmetric key")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &vault.VerifyResponse{
+		Verified: verifySignature(pub, stringParam(req, "message"), stringParam(req, "signature")),
+	})
+}
+
+// verifyDetached verifies a signature using the hex-encoded Ed25519 or PEM-encoded RSA public
+// key given in the request params
+func (s *Server) verifyDetached(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	var pub crypto.PublicKey
+	publicKey := stringParam(req, "public_key")
+
+	switch spec := stringParam(req, "spec"); spec {
+	case vault.KeySpecECCEd25519:
+		raw, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			writeError(w, http.StatusUnprocessableEntity, "invalid Ed25519 public key")
+			return
+		}
+		pub = ed25519.PublicKey(raw)
+	case vault.KeySpecRSA2048, vault.KeySpecRSA3072, vault.KeySpecRSA4096:
+		block, _ := pem.Decode([]byte(publicKey))
+		if block == nil {
+			writeError(w, http.StatusUnprocessableEntity, "invalid RSA public key")
+			return
+		}
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, "invalid RSA public key")
+			return
+		}
+	default:
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("unsupported key spec: %s", spec))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &vault.VerifyResponse{
+		Verified: verifySignature(pub, stringParam(req, "message"), stringParam(req, "signature")),
+	})
+}
+
+// encrypt writes the hex-encoded nonce and ciphertext of the data request param; the nonce is
+// randomly generated unless given as a hex-encoded request param
+func (s *Server) encrypt(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.keyRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	if key.symmetric == nil {
+		writeError(w, http.StatusUnprocessableEntity, "encryption requires a symmetric key")
+		return
+	}
+
+	aead, err := newAEAD(key.symmetric)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if n := stringParam(req, "nonce"); n != "" {
+		nonce, err = hex.DecodeString(n)
+		if err != nil || len(nonce) != aead.NonceSize() {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("nonce must be %d hex-encoded bytes", aead.NonceSize()))
+			return
+		}
+	} else if _, err := rand.Read(nonce); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ciphertext := aead.Seal(nonce, nonce, []byte(stringParam(req, "data")), nil)
+	data := hex.EncodeToString(ciphertext)
+	encodedNonce := hex.EncodeToString(nonce)
+	writeJSON(w, http.StatusOK, &vault.EncryptDecryptRequestResponse{
+		Data:  data,
+		Nonce: &encodedNonce,
+	})
+}
+
+// decrypt writes the plaintext of the data request param, which is the hex-encoded nonce and
+// ciphertext previously returned by encrypt
+func (s *Server) decrypt(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req, key, ok := s.keyRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	if key.symmetric == nil {
+		writeError(w, http.StatusUnprocessableEntity, "decryption requires a symmetric key")
+		return
+	}
+
+	aead, err := newAEAD(key.symmetric)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ciphertext, err := hex.DecodeString(stringParam(req, "data"))
+	if err != nil || len(ciphertext) < aead.NonceSize() {
+		writeError(w, http.StatusUnprocessableEntity, "invalid ciphertext")
+		return
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "failed to decrypt ciphertext")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &vault.EncryptDecryptRequestResponse{
+		Data: string(plaintext),
+	})
+}
+
+func (s *Server) listSecrets(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	secrets := s.store.find("secrets", func(secret map[string]interface{}) bool {
+		return secret["vault_id"] == params["id"]
+	})
+	for _, secret := range secrets {
+		delete(secret, "value")
+	}
+	writePage(w, r, secrets)
+}
+
+func (s *Server) createSecret(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.store.get("vaults", params["id"]); !ok {
+		writeError(w, http.StatusNotFound, "vault not found")
+		return
+	}
+	s.create("secrets", http.StatusCreated, map[string]string{"id": "vault_id"})(w, r, params)
+}
+
+func (s *Server) generateUnsealerKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	key := hex.EncodeToString(raw)
+	validationHash := fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+
+	s.mutex.Lock()
+	s.unsealerKey = key
+	s.mutex.Unlock()
+
+	writeJSON(w, http.StatusCreated, &vault.SealUnsealRequestResponse{
+		UnsealerKey:    &key,
+		ValidationHash: &validationHash,
+	})
+}
+
+func (s *Server) seal(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if !s.checkUnsealerKey(w, r) {
+		return
+	}
+
+	s.mutex.Lock()
+	s.sealed = true
+	s.mutex.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) unseal(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if !s.checkUnsealerKey(w, r) {
+		return
+	}
+
+	s.mutex.Lock()
+	s.sealed = false
+	s.mutex.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkUnsealerKey writes an error unless the key request param matches the most recently
+// generated unsealer key
+func (s *Server) checkUnsealerKey(w http.ResponseWriter, r *http.Request) bool {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return false
+	}
+
+	s.mutex.Lock()
+	unsealerKey := s.unsealerKey
+	s.mutex.Unlock()
+
+	if unsealerKey == "" || stringParam(req, "key") != unsealerKey {
+		writeError(w, http.StatusUnprocessableEntity, "invalid unsealer key")
+		return false
+	}
+	return true
+}
+
+// keyRequest reads the request params and resolves the key material for the key_id path
+// parameter; an error is written, and false returned, if the key cannot be used
+func (s *Server) keyRequest(w http.ResponseWriter, r *http.Request, params map[string]string) (map[string]interface{}, *keyMaterial, bool) {
+	req, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return nil, nil, false
+	}
+
+	s.mutex.Lock()
+	key, ok := s.keys[params["key_id"]]
+	sealed := s.sealed
+	s.mutex.Unlock()
+
+	if sealed {
+		writeError(w, http.StatusServiceUnavailable, "vault is sealed")
+		return nil, nil, false
+	}
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "key not found")
+		return nil, nil, false
+	}
+
+	return req, key, true
+}
+
+func generateKey(spec string) (*keyMaterial, error) {
+	key := &keyMaterial{spec: spec}
+	var err error
+
+	switch spec {
+	case vault.KeySpecAES256GCM:
+		key.symmetric = make([]byte, 32)
+		_, err = rand.Read(key.symmetric)
+	case vault.KeySpecECCEd25519:
+		_, key.ed25519, err = ed25519.GenerateKey(rand.Reader)
+	case vault.KeySpecRSA2048:
+		key.rsa, err = rsa.GenerateKey(rand.Reader, vault.KeyBits2048)
+	case vault.KeySpecRSA3072:
+		key.rsa, err = rsa.GenerateKey(rand.Reader, vault.KeyBits3072)
+	case vault.KeySpecRSA4096:
+		key.rsa, err = rsa.GenerateKey(rand.Reader, vault.KeyBits4096)
+	default:
+		return nil, fmt.Errorf("unsupported key spec: %s", spec)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// publicKey returns the hex-encoded Ed25519 or PEM-encoded RSA public key
+func (k *keyMaterial) publicKey() string {
+	switch {
+	case k.ed25519 != nil:
+		return hex.EncodeToString(k.ed25519.Public().(ed25519.PublicKey))
+	case k.rsa != nil:
+		der, err := x509.MarshalPKIXPublicKey(&k.rsa.PublicKey)
+		if err != nil {
+			return ""
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+	return ""
+}
+
+func verifySignature(pub crypto.PublicKey, msg, signature string) bool {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil {
+		return false
+	}
+
+	switch pub := pub.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(pub, []byte(msg), sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256([]byte(msg))
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+	}
+	return false
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
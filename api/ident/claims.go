@@ -0,0 +1,215 @@
+package ident
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	uuid "github.com/kthomas/go.uuid"
+)
+
+// NATSClaimsKey is the claim of an ident JWT which carries its NATS claims
+const NATSClaimsKey = "nats"
+
+// The subject types of the sub claim of an ident JWT, which has the form <type>:<id>
+const (
+	SubjectApplication  = "application"
+	SubjectInvitation   = "invitation"
+	SubjectOrganization = "organization"
+	SubjectUser         = "user"
+)
+
+// ApplicationClaims are the Provide application claims of an ident JWT, which identify the
+// application, organization and user on whose behalf the token was vended
+type ApplicationClaims struct {
+	ApplicationID  *uuid.UUID             `json:"application_id,omitempty"`
+	OrganizationID *uuid.UUID             `json:"organization_id,omitempty"`
+	UserID         *uuid.UUID             `json:"user_id,omitempty"`
+	Permissions    Permission             `json:"permissions,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+}
+
+// NATSClaims are the NATS claims of an ident JWT which authorizes a NATS connection
+type NATSClaims struct {
+	Permissions *NATSPermissions `json:"permissions,omitempty"`
+}
+
+// NATSPermissions are the subjects a NATS connection may publish and subscribe to
+type NATSPermissions struct {
+	Publish   *NATSSubjectPermission `json:"publish,omitempty"`
+	Subscribe *NATSSubjectPermission `json:"subscribe,omitempty"`
+}
+
+// NATSSubjectPermission lists the allowed and denied NATS subjects, which may include the
+// * and > wildcards
+type NATSSubjectPermission struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// ProvideClaims are the Provide-specific claims of an ident JWT: its subject, application
+// claims and NATS claims
+type ProvideClaims struct {
+	Subject     string             `json:"sub,omitempty"`
+	Application *ApplicationClaims `json:"prvd,omitempty"`
+	NATS        *NATSClaims        `json:"nats,omitempty"`
+}
+
+// ParseProvideClaims parses the Provide-specific claims from the claims of a JWT, such as a
+// jwt.MapClaims
+func ParseProvideClaims(claims map[string]interface{}) (*ProvideClaims, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"sub":                claims["sub"],
+		ApplicationClaimsKey: claims[ApplicationClaimsKey],
+		NATSClaimsKey:        claims[NATSClaimsKey],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	provideClaims := &ProvideClaims{}
+	if err := json.Unmarshal(raw, provideClaims); err != nil {
+		return nil, fmt.Errorf("failed to parse provide claims; %s", err.Error())
+	}
+	return provideClaims, nil
+}
+
+// SubjectType returns the type of the subject, e.g. SubjectApplication, or an empty string if
+// the subject is malformed
+func (c *ProvideClaims) SubjectType() string {
+	segments := strings.SplitN(c.Subject, ":", 2)
+	if len(segments) != 2 {
+		return ""
+	}
+	return segments[0]
+}
+
+// SubjectID returns the id of the subject, or nil if the subject is malformed
+func (c *ProvideClaims) SubjectID() *uuid.UUID {
+	segments := strings.SplitN(c.Subject, ":", 2)
+	if len(segments) != 2 {
+		return nil
+	}
+
+	id, err := uuid.FromString(segments[1])
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// ApplicationID returns the id of the application subject, or the application_id claim
+func (c *ProvideClaims) ApplicationID() *uuid.UUID {
+	return c.resolveID(SubjectApplication, func(a *ApplicationClaims) *uuid.UUID { return a.ApplicationID })
+}
+
+// OrganizationID returns the id of the organization subject, or the organization_id claim
+func (c *ProvideClaims) OrganizationID() *uuid.UUID {
+	return c.resolveID(SubjectOrganization, func(a *ApplicationClaims) *uuid.UUID { return a.OrganizationID })
+}
+
+// UserID returns the id of the user subject, or the user_id claim
+func (c *ProvideClaims) UserID() *uuid.UUID {
+	return c.resolveID(SubjectUser, func(a *ApplicationClaims) *uuid.UUID { return a.UserID })
+}
+
+// Permissions returns the permissions authorized by the application claims
+func (c *ProvideClaims) Permissions() Permission {
+	if c.Application == nil {
+		return 0
+	}
+	return c.Application.Permissions
+}
+
+func (c *ProvideClaims) resolveID(subjectType string, claim func(*ApplicationClaims) *uuid.UUID) *uuid.UUID {
+	if c.SubjectType() == subjectType {
+		return c.SubjectID()
+	}
+	if c.Application != nil {
+		return claim(c.Application)
+	}
+	return nil
+}
+
+// validate requires the application claims, and requires the id of an application,
+// organization or user subject to match the corresponding application claim
+func (c *ProvideClaims) validate() error {
+	if c.Application == nil {
+		return fmt.Errorf("%s claim required", ApplicationClaimsKey)
+	}
+
+	subjectType := c.SubjectType()
+	if subjectType == "" {
+		return fmt.Errorf("malformed subject: %s", c.Subject)
+	}
+
+	var id *uuid.UUID
+	switch subjectType {
+	case SubjectApplication:
+		id = c.Application.ApplicationID
+	case SubjectOrganization:
+		id = c.Application.OrganizationID
+	case SubjectUser:
+		id = c.Application.UserID
+	default:
+		return nil
+	}
+
+	subjectID := c.SubjectID()
+	if id == nil || subjectID == nil || *id != *subjectID {
+		return fmt.Errorf("%s claims do not match subject: %s", ApplicationClaimsKey, c.Subject)
+	}
+	return nil
+}
+
+// CanPublish returns true if the NATS claims permit publishing to the given subject
+func (c *ProvideClaims) CanPublish(subject string) bool {
+	if c.NATS == nil || c.NATS.Permissions == nil {
+		return false
+	}
+	return c.NATS.Permissions.Publish.permits(subject)
+}
+
+// CanSubscribe returns true if the NATS claims permit subscribing to the given subject
+func (c *ProvideClaims) CanSubscribe(subject string) bool {
+	if c.NATS == nil || c.NATS.Permissions == nil {
+		return false
+	}
+	return c.NATS.Permissions.Subscribe.permits(subject)
+}
+
+// permits returns true if the subject matches an allowed subject and no denied subject
+func (p *NATSSubjectPermission) permits(subject string) bool {
+	if p == nil {
+		return false
+	}
+
+	for _, denied := range p.Deny {
+		if natsSubjectMatches(denied, subject) {
+			return false
+		}
+	}
+	for _, allowed := range p.Allow {
+		if natsSubjectMatches(allowed, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// natsSubjectMatches returns true if the subject matches the pattern, in which * matches a
+// single token and a trailing > matches one or more tokens
+func natsSubjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" && i == len(patternTokens)-1 {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
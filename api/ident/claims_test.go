@@ -0,0 +1,86 @@
+package ident
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPermission(t *testing.T) {
+	p := PermissionAuthenticate.Add(PermissionReadResources | PermissionCreateResource)
+	if !p.Has(PermissionAuthenticate | PermissionReadResources) {
+		t.Errorf("expected %s to have authenticate and read_resources", p)
+	}
+	if p.Has(PermissionReadResources | PermissionDeleteResource) {
+		t.Errorf("expected %s not to have delete_resource", p)
+	}
+
+	p = p.Remove(PermissionReadResources)
+	if p.Has(PermissionReadResources) || !p.Has(PermissionCreateResource) {
+		t.Errorf("expected only read_resources to be removed; got %s", p)
+	}
+
+	if s := (p | 0x100).String(); s != "authenticate|create_resource|0x100" {
+		t.Errorf("unexpected permission string: %s", s)
+	}
+}
+
+func TestParseProvideClaims(t *testing.T) {
+	claims := map[string]interface{}{}
+	err := json.Unmarshal([]byte(`{
+		"sub": "user:8f3ac6a4-4b7c-4c47-b1a5-52a4b6e6a1b4",
+		"prvd": {
+			"application_id": "0d6d5a40-9b0c-4a7e-8d0e-6f6c2a0d1c6e",
+			"user_id": "8f3ac6a4-4b7c-4c47-b1a5-52a4b6e6a1b4",
+			"permissions": 7
+		},
+		"nats": {
+			"permissions": {
+				"publish": {"allow": ["baseline.>"], "deny": ["baseline.admin.*"]},
+				"subscribe": {"allow": ["network.*.status"]}
+			}
+		}
+	}`), &claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provideClaims, err := ParseProvideClaims(claims)
+	if err != nil {
+		t.Fatalf("failed to parse provide claims; %s", err.Error())
+	}
+	if err := provideClaims.validate(); err != nil {
+		t.Errorf("expected claims to be valid; %s", err.Error())
+	}
+
+	if provideClaims.SubjectType() != SubjectUser || provideClaims.UserID().String() != "8f3ac6a4-4b7c-4c47-b1a5-52a4b6e6a1b4" {
+		t.Errorf("unexpected user subject: %s", provideClaims.Subject)
+	}
+	if id := provideClaims.ApplicationID(); id == nil || id.String() != "0d6d5a40-9b0c-4a7e-8d0e-6f6c2a0d1c6e" {
+		t.Errorf("unexpected application id: %v", id)
+	}
+	if provideClaims.OrganizationID() != nil {
+		t.Error("expected no organization id")
+	}
+	if !provideClaims.Permissions().Has(PermissionAuthenticate | PermissionReadResources | PermissionCreateResource) {
+		t.Errorf("unexpected permissions: %s", provideClaims.Permissions())
+	}
+
+	for subject, expected := range map[string]bool{
+		"baseline.workgroup.created": true,
+		"baseline":                   false,
+		"baseline.admin.reset":       false,
+		"baseline.admin.reset.now":   true,
+	} {
+		if provideClaims.CanPublish(subject) != expected {
+			t.Errorf("expected publishing to %s to be permitted: %v", subject, expected)
+		}
+	}
+	if !provideClaims.CanSubscribe("network.abc.status") || provideClaims.CanSubscribe("network.abc.blocks") {
+		t.Error("unexpected subscribe permissions")
+	}
+
+	provideClaims.Application.UserID = provideClaims.Application.ApplicationID
+	if err := provideClaims.validate(); err == nil {
+		t.Error("expected claims not matching the subject to be invalid")
+	}
+}
@@ -0,0 +1,167 @@
+package ident
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	uuid "github.com/kthomas/go.uuid"
+)
+
+// DefaultInviteTTL is the lifetime of an invite token signed without an explicit ttl
+const DefaultInviteTTL = time.Hour * 72
+
+// JWTSigner signs the given claims, returning the encoded JWT; it is implemented by
+// util.JWTKeypair
+type JWTSigner interface {
+	SignJWT(claims jwt.Claims) (string, error)
+}
+
+// KeyResolver resolves the public key which verifies a JWT having the given key id; it is
+// implemented by *KeySet and util.JWTKeypair
+type KeyResolver interface {
+	ResolveKey(ctx context.Context, kid string) (interface{}, error)
+}
+
+// inviteClaims are the claims of an invite token; the invitation id is the jti, and the
+// invite fields not carried by the application claims are its application claims data
+type inviteClaims struct {
+	ID          string             `json:"jti,omitempty"`
+	Subject     string             `json:"sub,omitempty"`
+	IssuedAt    int64              `json:"iat,omitempty"`
+	ExpiresAt   int64              `json:"exp,omitempty"`
+	NotBefore   int64              `json:"nbf,omitempty"`
+	Application *ApplicationClaims `json:"prvd,omitempty"`
+}
+
+// Valid is a no-op; the claims are validated by VerifyInvite rather than the parser
+func (c *inviteClaims) Valid() error {
+	return nil
+}
+
+// SignInvite returns a signed invite token for the given invite which expires after the
+// given ttl, or DefaultInviteTTL if it is zero; an id is assigned to the invite if it has none
+func SignInvite(signer JWTSigner, invite *Invite, ttl time.Duration) (string, error) {
+	if signer == nil {
+		return "", errors.New("failed to sign invite; no signer provided")
+	}
+	if invite == nil || invite.Email == nil {
+		return "", errors.New("failed to sign invite; email required")
+	}
+
+	if invite.ID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return "", fmt.Errorf("failed to sign invite; %s", err.Error())
+		}
+		invite.ID = id
+	}
+	if ttl == 0 {
+		ttl = DefaultInviteTTL
+	}
+
+	data, err := inviteData(invite)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign invite; %s", err.Error())
+	}
+
+	now := time.Now()
+	return signer.SignJWT(&inviteClaims{
+		ID:        invite.ID.String(),
+		Subject:   fmt.Sprintf("%s:%s", SubjectInvitation, invite.ID.String()),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		NotBefore: now.Unix(),
+		Application: &ApplicationClaims{
+			ApplicationID:  invite.ApplicationID,
+			OrganizationID: invite.OrganizationID,
+			UserID:         invite.UserID,
+			Permissions:    invite.Permissions,
+			Data:           data,
+		},
+	})
+}
+
+// VerifyInvite verifies the signature and expiration of the given invite token, resolving
+// its key using the given resolver, and returns the invite it encodes
+func VerifyInvite(ctx context.Context, keys KeyResolver, token string) (*Invite, error) {
+	if keys == nil {
+		return nil, errors.New("failed to verify invite; no key resolver provided")
+	}
+
+	claims := &inviteClaims{}
+	parser := &jwt.Parser{
+		ValidMethods:         []string{"RS256", "RS384", "RS512"},
+		SkipClaimsValidation: true,
+	}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return keys.ResolveKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidJWT, err.Error())
+	}
+
+	now := time.Now()
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w; exp claim required", ErrInvalidJWT)
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, fmt.Errorf("%w; invite expired", ErrInvalidJWT)
+	}
+	if claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("%w; invite not yet valid", ErrInvalidJWT)
+	}
+
+	subject := ProvideClaims{Subject: claims.Subject}
+	id := subject.SubjectID()
+	if subject.SubjectType() != SubjectInvitation || id == nil || id.String() != claims.ID {
+		return nil, fmt.Errorf("%w; malformed invite subject: %s", ErrInvalidJWT, claims.Subject)
+	}
+	if claims.Application == nil {
+		return nil, fmt.Errorf("%w; %s claim required", ErrInvalidJWT, ApplicationClaimsKey)
+	}
+
+	invite := &Invite{}
+	if claims.Application.Data != nil {
+		raw, err := json.Marshal(claims.Application.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode invite; %s", err.Error())
+		}
+		if err := json.Unmarshal(raw, invite); err != nil {
+			return nil, fmt.Errorf("failed to decode invite; %s", err.Error())
+		}
+	}
+
+	invite.ID = *id
+	if claims.IssuedAt != 0 {
+		invite.CreatedAt = time.Unix(claims.IssuedAt, 0)
+	}
+	invite.ApplicationID = claims.Application.ApplicationID
+	invite.OrganizationID = claims.Application.OrganizationID
+	invite.UserID = claims.Application.UserID
+	invite.Permissions = claims.Application.Permissions
+	return invite, nil
+}
+
+// inviteData returns the fields of the invite which are not carried by the id or the
+// application claims of an invite token
+func inviteData(invite *Invite) (map[string]interface{}, error) {
+	raw, err := json.Marshal(invite)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{"id", "created_at", "errors", "application_id", "organization_id", "user_id", "permissions"} {
+		delete(data, key)
+	}
+	return data, nil
+}
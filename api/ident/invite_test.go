@@ -0,0 +1,90 @@
+package ident
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	uuid "github.com/kthomas/go.uuid"
+)
+
+type testKeypair struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+func (k *testKeypair) SignJWT(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = k.kid
+	return token.SignedString(k.key)
+}
+
+func (k *testKeypair) ResolveKey(ctx context.Context, kid string) (interface{}, error) {
+	if kid != k.kid {
+		return nil, errors.New("unknown kid")
+	}
+	return &k.key.PublicKey, nil
+}
+
+func TestSignAndVerifyInvite(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keypair := &testKeypair{kid: "test", key: key}
+
+	email := "invitee@example.com"
+	organizationName := "Acme"
+	organizationID, _ := uuid.NewV4()
+	invite := &Invite{
+		Email:            &email,
+		OrganizationID:   &organizationID,
+		OrganizationName: &organizationName,
+		Permissions:      DefaultPermissions,
+		Params:           map[string]interface{}{"workgroup": "baseline"},
+	}
+
+	token, err := SignInvite(keypair, invite, 0)
+	if err != nil {
+		t.Fatalf("failed to sign invite; %s", err.Error())
+	}
+	if invite.ID == uuid.Nil {
+		t.Fatal("expected an id to be assigned to the invite")
+	}
+
+	verified, err := VerifyInvite(context.Background(), keypair, token)
+	if err != nil {
+		t.Fatalf("failed to verify invite; %s", err.Error())
+	}
+	if verified.ID != invite.ID || *verified.Email != email || *verified.OrganizationName != organizationName {
+		t.Errorf("unexpected invite: %+v", verified)
+	}
+	if verified.OrganizationID == nil || *verified.OrganizationID != organizationID || verified.ApplicationID != nil {
+		t.Errorf("unexpected invite organization and application: %v, %v", verified.OrganizationID, verified.ApplicationID)
+	}
+	if verified.Permissions != DefaultPermissions || verified.Params["workgroup"] != "baseline" {
+		t.Errorf("unexpected invite permissions and params: %s, %v", verified.Permissions, verified.Params)
+	}
+
+	expired, err := SignInvite(keypair, invite, -time.Minute)
+	if err != nil {
+		t.Fatalf("failed to sign invite; %s", err.Error())
+	}
+	if _, err := VerifyInvite(context.Background(), keypair, expired); !errors.Is(err, ErrInvalidJWT) {
+		t.Errorf("expected expired invite to be invalid; got %v", err)
+	}
+
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	forged, _ := SignInvite(&testKeypair{kid: "test", key: other}, invite, 0)
+	if _, err := VerifyInvite(context.Background(), keypair, forged); !errors.Is(err, ErrInvalidJWT) {
+		t.Errorf("expected invite signed by another key to be invalid; got %v", err)
+	}
+
+	if _, err := SignInvite(keypair, &Invite{}, 0); err == nil {
+		t.Error("expected invite without email not to be signed")
+	}
+}
@@ -0,0 +1,203 @@
+package ident
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// DefaultKeyRefreshInterval is the default interval after which the keys cached by a KeySet
+// are refreshed
+const DefaultKeyRefreshInterval = time.Minute * 15
+
+// minKeyRefreshInterval limits how often a KeySet refetches its keys upon encountering an
+// unknown key id, so tokens having bogus key ids cannot be used to flood ident
+const minKeyRefreshInterval = time.Second * 30
+
+const keyRefreshTimeout = time.Second * 30
+
+// RSAPublicKey returns the RSA public key of the JWK, from its PEM-encoded public key, its
+// X.509 certificate chain or otherwise its modulus and exponent
+func (k *JSONWebKey) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.PublicKey != "" {
+		block, _ := pem.Decode([]byte(k.PublicKey))
+		if block == nil {
+			return nil, errors.New("invalid PEM-encoded public key")
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return rsaPublicKey(key)
+	}
+
+	if len(k.X5c) > 0 {
+		der, err := base64.StdEncoding.DecodeString(k.X5c[0])
+		if err != nil {
+			return nil, errors.New("invalid x5c certificate encoding")
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		return rsaPublicKey(cert.PublicKey)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func rsaPublicKey(key interface{}) (*rsa.PublicKey, error) {
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// KeySet caches the public keys which ident publishes at its well-known keys endpoint. Keys
+// older than RefreshInterval continue to be served while they are refreshed in the background,
+// and the keys are refetched when a key id is not found, at most every 30 seconds.
+type KeySet struct {
+	RefreshInterval time.Duration
+
+	service    *Service
+	keys       map[string]*rsa.PublicKey
+	refreshed  time.Time
+	refreshing bool
+	mutex      sync.Mutex
+}
+
+// NewKeySet returns a *KeySet which fetches keys using the given ident service, or the
+// service configured in the environment if it is nil
+func NewKeySet(service *Service) *KeySet {
+	if service == nil {
+		service = InitIdentService(nil)
+	}
+
+	return &KeySet{
+		RefreshInterval: DefaultKeyRefreshInterval,
+		service:         service,
+		keys:            map[string]*rsa.PublicKey{},
+	}
+}
+
+// ResolveKey returns the RSA public key having the given key id; when the id is empty, ident
+// must publish a single key
+func (k *KeySet) ResolveKey(ctx context.Context, kid string) (interface{}, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if key, ok := k.lookup(kid); ok {
+		if time.Since(k.refreshed) > k.refreshInterval() && !k.refreshing {
+			k.refreshing = true
+			go k.refreshInBackground()
+		}
+		return key, nil
+	}
+
+	if k.refreshed.IsZero() || time.Since(k.refreshed) > minKeyRefreshInterval {
+		if err := k.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no ident key found for kid: %s", kid)
+}
+
+// Refresh fetches the keys published by ident, replacing the cached keys
+func (k *KeySet) Refresh(ctx context.Context) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.refresh(ctx)
+}
+
+func (k *KeySet) refreshInterval() time.Duration {
+	if k.RefreshInterval <= 0 {
+		return DefaultKeyRefreshInterval
+	}
+	return k.RefreshInterval
+}
+
+func (k *KeySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// refresh fetches and caches the keys; the caller must hold the mutex
+func (k *KeySet) refresh(ctx context.Context) error {
+	keys, err := k.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	k.keys = keys
+	k.refreshed = time.Now()
+	return nil
+}
+
+func (k *KeySet) refreshInBackground() {
+	ctx, cancel := context.WithTimeout(context.Background(), keyRefreshTimeout)
+	defer cancel()
+
+	keys, err := k.fetch(ctx)
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.refreshing = false
+	if err != nil {
+		common.Log.Warningf("failed to refresh ident jwt keys; %s", err.Error())
+		return
+	}
+	k.keys = keys
+	k.refreshed = time.Now()
+}
+
+func (k *KeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	jwks, err := k.service.GetJWKs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks {
+		key, err := jwk.RSAPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ident key: %s; %s", jwk.Kid, err.Error())
+		}
+		keys[jwk.Kid] = key
+	}
+
+	common.Log.Tracef("fetched %d ident jwt key(s)", len(keys))
+	return keys, nil
+}
@@ -0,0 +1,147 @@
+package ident
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// ApplicationClaimsKey is the claim of an ident JWT which carries its application claims
+const ApplicationClaimsKey = "prvd"
+
+// ErrInvalidJWT is returned when a JWT fails verification
+var ErrInvalidJWT = errors.New("invalid jwt")
+
+// Audience is the aud claim of a JWT, which may be encoded as a string or an array of strings
+type Audience []string
+
+// Contains returns true if the audience includes the given audience
+func (a Audience) Contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes a single audience as a string, and otherwise as an array of strings
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON decodes an audience encoded as a string or an array of strings
+func (a *Audience) UnmarshalJSON(raw []byte) error {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(raw, &multiple); err != nil {
+		return fmt.Errorf("failed to parse aud claim; %s", err.Error())
+	}
+	*a = multiple
+	return nil
+}
+
+// JWTClaims are the claims of a verified ident JWT
+type JWTClaims struct {
+	ProvideClaims
+
+	ID        string   `json:"jti,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+}
+
+// jwtClaims adapts JWTClaims to jwt.Claims; the claims are validated by the JWTVerifier
+// rather than the parser, so the leeway applies
+type jwtClaims struct {
+	*JWTClaims
+}
+
+func (c *jwtClaims) Valid() error {
+	return nil
+}
+
+// JWTVerifier verifies the JWTs issued by ident offline, using the keys cached by a KeySet.
+// Tokens must be RS256-, RS384- or RS512-signed, unexpired, carry application claims
+// consistent with their subject and, when Audience and Issuer are set, the given aud and iss
+// claims.
+type JWTVerifier struct {
+	Audience string
+	Issuer   string
+
+	// Leeway is the allowed clock skew when validating the exp and nbf claims
+	Leeway time.Duration
+
+	keys *KeySet
+	now  func() time.Time
+}
+
+// NewJWTVerifier returns a *JWTVerifier which resolves keys using the given key set, or a new
+// key set for the ident service configured in the environment if it is nil
+func NewJWTVerifier(keys *KeySet, audience, issuer string) *JWTVerifier {
+	if keys == nil {
+		keys = NewKeySet(nil)
+	}
+
+	return &JWTVerifier{
+		Audience: audience,
+		Issuer:   issuer,
+		keys:     keys,
+		now:      time.Now,
+	}
+}
+
+// Verify verifies the signature and claims of the given JWT and returns its claims
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*JWTClaims, error) {
+	claims := &JWTClaims{}
+	parser := &jwt.Parser{
+		ValidMethods:         []string{"RS256", "RS384", "RS512"},
+		SkipClaimsValidation: true,
+	}
+	_, err := parser.ParseWithClaims(token, &jwtClaims{claims}, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.ResolveKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidJWT, err.Error())
+	}
+
+	now := v.now()
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w; exp claim required", ErrInvalidJWT)
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(v.Leeway)) {
+		return nil, fmt.Errorf("%w; token expired", ErrInvalidJWT)
+	}
+	if claims.NotBefore != 0 && now.Add(v.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("%w; token not yet valid", ErrInvalidJWT)
+	}
+
+	if v.Audience != "" && !claims.Audience.Contains(v.Audience) {
+		return nil, fmt.Errorf("%w; invalid audience", ErrInvalidJWT)
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return nil, fmt.Errorf("%w; invalid issuer", ErrInvalidJWT)
+	}
+
+	if err := claims.validate(); err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidJWT, err.Error())
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,54 @@
+package ident_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api/fake"
+	"github.com/provideplatform/provide-go/api/ident"
+)
+
+func TestJWTVerifier(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	usr, err := ident.CreateUser("", map[string]interface{}{
+		"email":    "user@example.com",
+		"password": "s3cr3t",
+	})
+	if err != nil {
+		t.Fatalf("failed to create user; %s", err.Error())
+	}
+	resp, err := ident.Authenticate("user@example.com", "s3cr3t")
+	if err != nil {
+		t.Fatalf("failed to authenticate; %s", err.Error())
+	}
+
+	keys := ident.NewKeySet(nil)
+	verifier := ident.NewJWTVerifier(keys, srv.Ident.URL, "")
+	claims, err := verifier.Verify(context.Background(), *resp.Token.AccessToken)
+	if err != nil {
+		t.Fatalf("failed to verify access token; %s", err.Error())
+	}
+	if claims.Subject != fmt.Sprintf("user:%s", usr.ID) {
+		t.Errorf("expected user subject; got %s", claims.Subject)
+	}
+	if claims.Application == nil || claims.Application.UserID == nil || *claims.Application.UserID != usr.ID {
+		t.Errorf("expected prvd user_id claim; got %+v", claims.Application)
+	}
+
+	segments := strings.Split(*resp.Token.AccessToken, ".")
+	segments[2] = segments[2][:len(segments[2])-4] + "AAAA"
+	if _, err := verifier.Verify(context.Background(), strings.Join(segments, ".")); !errors.Is(err, ident.ErrInvalidJWT) {
+		t.Errorf("expected tampered token to be rejected; got %v", err)
+	}
+
+	verifier = ident.NewJWTVerifier(keys, "https://other.example.com", "")
+	if _, err := verifier.Verify(context.Background(), *resp.Token.AccessToken); !errors.Is(err, ident.ErrInvalidJWT) {
+		t.Errorf("expected token for another audience to be rejected; got %v", err)
+	}
+}
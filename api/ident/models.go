@@ -41,7 +41,7 @@ type Invite struct {
 	InvitorName      *string                `json:"invitor_name,omitempty"`
 	OrganizationID   *uuid.UUID             `json:"organization_id,omitempty"`
 	OrganizationName *string                `json:"organization_name,omitempty"`
-	Permissions      uint32                 `json:"permissions,omitempty"`
+	Permissions      Permission             `json:"permissions,omitempty"`
 	Params           map[string]interface{} `json:"params,omitempty"`
 }
 
@@ -64,7 +64,7 @@ type Organization struct {
 	Name        *string                `json:"name"`
 	UserID      *uuid.UUID             `json:"user_id,omitempty"`
 	Description *string                `json:"description"`
-	Permissions uint32                 `json:"permissions,omitempty"`
+	Permissions Permission             `json:"permissions,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata"`
 }
 
@@ -90,7 +90,7 @@ type Token struct {
 	NotBefore *time.Time `json:"not_before_at,omitempty"`
 	Subject   *string    `json:"subject,omitempty"`
 
-	Permissions uint32                 `json:"permissions,omitempty"`
+	Permissions Permission             `json:"permissions,omitempty"`
 	Data        map[string]interface{} `json:"data,omitempty"`
 }
 
@@ -102,7 +102,7 @@ type User struct {
 	FirstName              string                 `json:"first_name"`
 	LastName               string                 `json:"last_name"`
 	Email                  string                 `json:"email"`
-	Permissions            uint32                 `json:"permissions,omitempty,omitempty"`
+	Permissions            Permission             `json:"permissions,omitempty,omitempty"`
 	PrivacyPolicyAgreedAt  *time.Time             `json:"privacy_policy_agreed_at,omitempty"`
 	TermsOfServiceAgreedAt *time.Time             `json:"terms_of_service_agreed_at,omitempty"`
 	Metadata               map[string]interface{} `json:"metadata,omitempty"`
@@ -0,0 +1,281 @@
+package ident
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// PKCECodeChallengeMethodS256 is the only PKCE code challenge method supported by ident
+const PKCECodeChallengeMethodS256 = "S256"
+
+const oauthAuthorizePath = "oauth/authorize"
+
+// PKCE is a Proof Key for Code Exchange (RFC 7636) pairing a secret code verifier, which is
+// kept by the client, with the code challenge sent in the authorization request
+type PKCE struct {
+	Verifier        string
+	Challenge       string
+	ChallengeMethod string
+}
+
+// NewPKCE generates a random code verifier and its S256 code challenge
+func NewPKCE() (*PKCE, error) {
+	verifier, err := randomURLSafeString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PKCE code verifier; %s", err.Error())
+	}
+
+	digest := sha256.Sum256([]byte(verifier))
+	return &PKCE{
+		Verifier:        verifier,
+		Challenge:       base64.RawURLEncoding.EncodeToString(digest[:]),
+		ChallengeMethod: PKCECodeChallengeMethodS256,
+	}, nil
+}
+
+// AuthorizationRequest describes an authorization code request on behalf of an application;
+// the same request is used to exchange the resulting authorization code, so it should be kept
+// (e.g. in the session of the user) until the redirect is received
+type AuthorizationRequest struct {
+	ClientID    string
+	RedirectURI string
+	Scope       string
+	State       string
+	PKCE        *PKCE
+}
+
+// AuthorizationURL returns the ident url to which the user is redirected to authorize the
+// given request; a random State is generated if none is set, and PKCE is required
+func AuthorizationURL(req *AuthorizationRequest) (string, error) {
+	return InitIdentService(nil).AuthorizationURL(req)
+}
+
+// AuthorizationURL returns the ident url to which the user is redirected to authorize the
+// given request; a random State is generated if none is set, and PKCE is required
+func (s *Service) AuthorizationURL(req *AuthorizationRequest) (string, error) {
+	if req.ClientID == "" || req.RedirectURI == "" {
+		return "", errors.New("client id and redirect uri are required to authorize an application")
+	}
+	if req.PKCE == nil {
+		return "", errors.New("PKCE is required to authorize an application")
+	}
+
+	if req.State == "" {
+		state, err := randomURLSafeString(16)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate authorization state; %s", err.Error())
+		}
+		req.State = state
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", req.ClientID)
+	query.Set("redirect_uri", req.RedirectURI)
+	query.Set("state", req.State)
+	query.Set("code_challenge", req.PKCE.Challenge)
+	query.Set("code_challenge_method", req.PKCE.ChallengeMethod)
+	if req.Scope != "" {
+		query.Set("scope", req.Scope)
+	}
+
+	authorizeURL := &url.URL{
+		Scheme:   s.Scheme,
+		Host:     s.Host,
+		Path:     path.Join("/", s.Path, oauthAuthorizePath),
+		RawQuery: query.Encode(),
+	}
+	return authorizeURL.String(), nil
+}
+
+// ExchangeAuthorizationCode exchanges the authorization code received by the redirect uri of
+// the given authorization request for an access token, and a refresh token if the
+// offline_access scope was authorized
+func ExchangeAuthorizationCode(code string, req *AuthorizationRequest) (*Token, error) {
+	return ExchangeAuthorizationCodeWithContext(context.Background(), code, req)
+}
+
+// ExchangeAuthorizationCodeWithContext exchanges the authorization code received by the
+// redirect uri of the given authorization request for an access token, and a refresh token if
+// the offline_access scope was authorized
+func ExchangeAuthorizationCodeWithContext(ctx context.Context, code string, req *AuthorizationRequest) (*Token, error) {
+	return InitIdentService(nil).ExchangeAuthorizationCode(ctx, code, req)
+}
+
+// ExchangeAuthorizationCode exchanges the authorization code received by the redirect uri of
+// the given authorization request for an access token, and a refresh token if the
+// offline_access scope was authorized
+func (s *Service) ExchangeAuthorizationCode(ctx context.Context, code string, req *AuthorizationRequest) (*Token, error) {
+	if req.PKCE == nil {
+		return nil, errors.New("PKCE code verifier is required to exchange an authorization code")
+	}
+
+	return s.grant(ctx, "authorization code", map[string]interface{}{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"client_id":     req.ClientID,
+		"redirect_uri":  req.RedirectURI,
+		"code_verifier": req.PKCE.Verifier,
+	})
+}
+
+// ClientCredentials authorizes an access token for the application having the given client id
+// and secret using the client_credentials grant
+func ClientCredentials(clientID, clientSecret, scope string) (*Token, error) {
+	return ClientCredentialsWithContext(context.Background(), clientID, clientSecret, scope)
+}
+
+// ClientCredentialsWithContext authorizes an access token for the application having the given
+// client id and secret using the client_credentials grant
+func ClientCredentialsWithContext(ctx context.Context, clientID, clientSecret, scope string) (*Token, error) {
+	return InitIdentService(nil).ClientCredentials(ctx, clientID, clientSecret, scope)
+}
+
+// ClientCredentials authorizes an access token for the application having the given client id
+// and secret using the client_credentials grant; the credentials are sent using HTTP basic
+// authentication in place of any token configured on the service
+func (s *Service) ClientCredentials(ctx context.Context, clientID, clientSecret, scope string) (*Token, error) {
+	client := s.Client
+	client.Token = nil
+	client.TokenSource = nil
+	client.Username = common.StringOrNil(clientID)
+	client.Password = common.StringOrNil(clientSecret)
+
+	params := map[string]interface{}{
+		"grant_type": "client_credentials",
+	}
+	if scope != "" {
+		params["scope"] = scope
+	}
+
+	return (&Service{client}).grant(ctx, "client credentials", params)
+}
+
+// RefreshAccessToken exchanges the given refresh token for a new access token; when ident
+// rotates refresh tokens, the returned RefreshToken replaces the given one, which is no longer
+// valid, and otherwise it is the given refresh token
+func RefreshAccessToken(refreshToken, scope string) (*Token, error) {
+	return RefreshAccessTokenWithContext(context.Background(), refreshToken, scope)
+}
+
+// RefreshAccessTokenWithContext exchanges the given refresh token for a new access token; when
+// ident rotates refresh tokens, the returned RefreshToken replaces the given one, which is no
+// longer valid, and otherwise it is the given refresh token
+func RefreshAccessTokenWithContext(ctx context.Context, refreshToken, scope string) (*Token, error) {
+	return InitIdentService(nil).RefreshAccessToken(ctx, refreshToken, scope)
+}
+
+// RefreshAccessToken exchanges the given refresh token for a new access token; when ident
+// rotates refresh tokens, the returned RefreshToken replaces the given one, which is no longer
+// valid, and otherwise it is the given refresh token
+func (s *Service) RefreshAccessToken(ctx context.Context, refreshToken, scope string) (*Token, error) {
+	client := s.Client
+	client.Token = common.StringOrNil(refreshToken)
+	client.TokenSource = nil
+
+	params := map[string]interface{}{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	}
+	if scope != "" {
+		params["scope"] = scope
+	}
+
+	token, err := (&Service{client}).grant(ctx, "refresh token", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == nil {
+		token.RefreshToken = common.StringOrNil(refreshToken)
+	}
+	return token, nil
+}
+
+// RevokeToken revokes the given access or refresh token on behalf of the given API token
+func RevokeToken(token, revokedToken string) error {
+	return RevokeTokenWithContext(context.Background(), token, revokedToken)
+}
+
+// RevokeTokenWithContext revokes the given access or refresh token on behalf of the given API token
+func RevokeTokenWithContext(ctx context.Context, token, revokedToken string) error {
+	return InitIdentService(common.StringOrNil(token)).RevokeToken(ctx, revokedToken)
+}
+
+// RevokeToken revokes the given access or refresh token, which is identified by its jti claim
+func (s *Service) RevokeToken(ctx context.Context, token string) error {
+	var claims struct {
+		ID *string `json:"jti"`
+	}
+	if !unverifiedClaims(token, &claims) || claims.ID == nil {
+		return errors.New("failed to revoke token; token has no jti claim")
+	}
+
+	return s.DeleteToken(ctx, *claims.ID)
+}
+
+// grant requests a token using the given grant params, and normalizes the response so the
+// access token and its lifetime are populated
+func (s *Service) grant(ctx context.Context, grantType string, params map[string]interface{}) (*Token, error) {
+	tkn := &Token{}
+	status, err := s.PostInto(ctx, "tokens", params, tkn)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to authorize token using %s grant; status: %v", grantType, status)
+	}
+
+	if tkn.AccessToken == nil {
+		tkn.AccessToken = tkn.Token
+	}
+	if tkn.AccessToken == nil {
+		return nil, fmt.Errorf("failed to authorize token using %s grant; no access token returned", grantType)
+	}
+
+	if tkn.ExpiresIn == nil {
+		if expiresAt := tokenExpiration(tkn); expiresAt != nil {
+			expiresIn := uint64(0)
+			if remaining := time.Until(*expiresAt); remaining > 0 {
+				expiresIn = uint64(remaining / time.Second)
+			}
+			tkn.ExpiresIn = &expiresIn
+		}
+	}
+
+	return tkn, nil
+}
+
+func randomURLSafeString(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// unverifiedClaims decodes the claims of the given JWT into v without verifying its signature
+func unverifiedClaims(token string, v interface{}) bool {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return false
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
@@ -0,0 +1,131 @@
+package ident_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/api/fake"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/api/vault"
+)
+
+func TestOAuthFlows(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	token := srv.Token()
+	app, err := ident.CreateApplication(token, map[string]interface{}{"name": "app"})
+	if err != nil {
+		t.Fatalf("failed to create application; %s", err.Error())
+	}
+	appID := app.ID.String()
+
+	secret, err := ident.CreateToken(token, map[string]interface{}{"application_id": appID})
+	if err != nil {
+		t.Fatalf("failed to vend application token; %s", err.Error())
+	}
+
+	_, err = ident.ClientCredentials(appID, "invalid", "")
+	if !errors.Is(err, api.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error for invalid client secret; got %v", err)
+	}
+
+	appToken, err := ident.ClientCredentials(appID, *secret.Token, "")
+	if err != nil {
+		t.Fatalf("failed to authorize client credentials; %s", err.Error())
+	}
+	if appToken.AccessToken == nil || appToken.ExpiresIn == nil || appToken.RefreshToken != nil {
+		t.Fatalf("expected access token and lifetime without refresh token; got %+v", appToken)
+	}
+
+	pkce, err := ident.NewPKCE()
+	if err != nil {
+		t.Fatalf("failed to generate PKCE; %s", err.Error())
+	}
+	authReq := &ident.AuthorizationRequest{
+		ClientID:    appID,
+		RedirectURI: "https://app.example.com/callback",
+		Scope:       "offline_access",
+		PKCE:        pkce,
+	}
+	authorizeURL, err := ident.AuthorizationURL(authReq)
+	if err != nil {
+		t.Fatalf("failed to build authorization url; %s", err.Error())
+	}
+
+	// the user consents by following the authorization url while signed in
+	req, _ := http.NewRequest(http.MethodGet, authorizeURL, nil)
+	req.Header.Set("Authorization", fmt.Sprintf("bearer %s", token))
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("failed to authorize; %s", err.Error())
+	}
+	resp.Body.Close()
+	redirect, err := resp.Location()
+	if err != nil {
+		t.Fatalf("expected redirect; got status %d", resp.StatusCode)
+	}
+	if redirect.Query().Get("state") != authReq.State {
+		t.Fatalf("expected state %q; got %q", authReq.State, redirect.Query().Get("state"))
+	}
+	code := redirect.Query().Get("code")
+
+	_, err = ident.ExchangeAuthorizationCode(code, &ident.AuthorizationRequest{
+		ClientID:    authReq.ClientID,
+		RedirectURI: authReq.RedirectURI,
+		PKCE:        &ident.PKCE{Verifier: "wrong"},
+	})
+	if err == nil {
+		t.Fatal("expected authorization code exchange to fail without the code verifier")
+	}
+
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("failed to authorize; %s", err.Error())
+	}
+	resp.Body.Close()
+	redirect, _ = resp.Location()
+
+	userToken, err := ident.ExchangeAuthorizationCode(redirect.Query().Get("code"), authReq)
+	if err != nil {
+		t.Fatalf("failed to exchange authorization code; %s", err.Error())
+	}
+	if userToken.AccessToken == nil || userToken.RefreshToken == nil || userToken.ExpiresIn == nil || userToken.Scope == nil {
+		t.Fatalf("expected access and refresh tokens; got %+v", userToken)
+	}
+
+	refreshed, err := ident.RefreshAccessToken(*userToken.RefreshToken, "")
+	if err != nil {
+		t.Fatalf("failed to refresh access token; %s", err.Error())
+	}
+	if *refreshed.RefreshToken == *userToken.RefreshToken {
+		t.Fatal("expected refresh token to be rotated")
+	}
+	if _, err = ident.RefreshAccessToken(*userToken.RefreshToken, ""); err == nil {
+		t.Fatal("expected rotated refresh token to be rejected")
+	}
+
+	err = ident.RevokeToken(token, *refreshed.RefreshToken)
+	if err != nil {
+		t.Fatalf("failed to revoke refresh token; %s", err.Error())
+	}
+	if _, err = ident.RefreshAccessToken(*refreshed.RefreshToken, ""); err == nil {
+		t.Fatal("expected revoked refresh token to be rejected")
+	}
+
+	err = ident.RevokeToken(token, *refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("failed to revoke access token; %s", err.Error())
+	}
+	_, err = vault.CreateVault(*refreshed.AccessToken, map[string]interface{}{"name": "test"})
+	if !errors.Is(err, api.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error for revoked access token; got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package ident
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Permission is a bitmask of the permissions granted to an application, organization or user,
+// or authorized by a token
+type Permission uint32
+
+const (
+	// PermissionAuthenticate permits authentication
+	PermissionAuthenticate Permission = 1 << iota
+
+	// PermissionReadResources permits reading resources
+	PermissionReadResources
+
+	// PermissionCreateResource permits creating resources
+	PermissionCreateResource
+
+	// PermissionUpdateResource permits updating resources
+	PermissionUpdateResource
+
+	// PermissionDeleteResource permits deleting resources
+	PermissionDeleteResource
+
+	// PermissionGrantResourceAuthorization permits granting access to resources
+	PermissionGrantResourceAuthorization
+
+	// PermissionRevokeResourceAuthorization permits revoking access to resources
+	PermissionRevokeResourceAuthorization
+)
+
+// DefaultPermissions are the permissions granted by default, i.e. to authenticate and to
+// create, read, update and delete resources
+const DefaultPermissions = PermissionAuthenticate | PermissionReadResources | PermissionCreateResource |
+	PermissionUpdateResource | PermissionDeleteResource
+
+var permissionNames = []struct {
+	permission Permission
+	name       string
+}{
+	{PermissionAuthenticate, "authenticate"},
+	{PermissionReadResources, "read_resources"},
+	{PermissionCreateResource, "create_resource"},
+	{PermissionUpdateResource, "update_resource"},
+	{PermissionDeleteResource, "delete_resource"},
+	{PermissionGrantResourceAuthorization, "grant_resource_authorization"},
+	{PermissionRevokeResourceAuthorization, "revoke_resource_authorization"},
+}
+
+// Has returns true if all of the given permissions are set
+func (p Permission) Has(permission Permission) bool {
+	return p&permission == permission
+}
+
+// Add returns the permissions with the given permissions set
+func (p Permission) Add(permission Permission) Permission {
+	return p | permission
+}
+
+// Remove returns the permissions with the given permissions cleared
+func (p Permission) Remove(permission Permission) Permission {
+	return p &^ permission
+}
+
+// String returns the names of the permissions which are set, separated by "|"; unnamed bits
+// are included in hexadecimal
+func (p Permission) String() string {
+	if p == 0 {
+		return "none"
+	}
+
+	names := make([]string, 0)
+	remaining := p
+	for _, named := range permissionNames {
+		if p.Has(named.permission) {
+			names = append(names, named.name)
+			remaining = remaining.Remove(named.permission)
+		}
+	}
+	if remaining != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint32(remaining)))
+	}
+	return strings.Join(names, "|")
+}
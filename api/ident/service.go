@@ -1,9 +1,8 @@
 package ident
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -20,63 +19,67 @@ type Service struct {
 
 // InitDefaultIdentService convenience method to initialize a default `ident.Service` (i.e., production) instance
 func InitDefaultIdentService(token *string) *Service {
-	return &Service{
-		api.Client{
-			Host:   defaultIdentHost,
-			Path:   defaultIdentPath,
-			Scheme: defaultIdentScheme,
-			Token:  token,
-		},
-	}
+	return NewServiceWithConfig(&api.Config{
+		Host:   defaultIdentHost,
+		Path:   defaultIdentPath,
+		Scheme: defaultIdentScheme,
+		Token:  token,
+	})
 }
 
-// InitIdentService convenience method to initialize an `ident.Service` instance
-func InitIdentService(token *string) *Service {
-	host := defaultIdentHost
-	if os.Getenv("IDENT_API_HOST") != "" {
-		host = os.Getenv("IDENT_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the ident api from the IDENT_API_HOST,
+// IDENT_API_PATH and IDENT_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("IDENT", defaultIdentHost, defaultIdentPath, defaultIdentScheme)
+}
 
-	path := defaultIdentPath
-	if os.Getenv("IDENT_API_PATH") != "" {
-		path = os.Getenv("IDENT_API_PATH")
+// NewService initializes an `ident.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultIdentScheme
-	if os.Getenv("IDENT_API_SCHEME") != "" {
-		scheme = os.Getenv("IDENT_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  token,
-		},
-	}
+// NewServiceWithConfig initializes an `ident.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitIdentService convenience method to initialize an `ident.Service` instance
+func InitIdentService(token *string) *Service {
+	config := ConfigFromEnv()
+	config.Token = token
+	return NewServiceWithConfig(config)
 }
 
 // Authenticate a user by email address and password, returning a newly-authorized API token
 func Authenticate(email, passwd string) (*AuthenticationResponse, error) {
-	prvd := InitIdentService(nil)
-	status, resp, err := prvd.Post("authenticate", map[string]interface{}{
+	return AuthenticateWithContext(context.Background(), email, passwd)
+}
+
+// AuthenticateWithContext authenticates a user by email address and password, returning a newly-authorized API token
+func AuthenticateWithContext(ctx context.Context, email, passwd string) (*AuthenticationResponse, error) {
+	return InitIdentService(nil).Authenticate(ctx, email, passwd)
+}
+
+// Authenticate a user by email address and password, returning a newly-authorized API token
+func (s *Service) Authenticate(ctx context.Context, email, passwd string) (*AuthenticationResponse, error) {
+	authresp := &AuthenticationResponse{}
+	status, err := s.PostInto(ctx, "authenticate", map[string]interface{}{
 		"email":    email,
 		"password": passwd,
 		"scope":    "offline_access",
-	})
+	}, authresp)
 	if err != nil {
 		return nil, err
 	}
 
-	// FIXME...
-	authresp := &AuthenticationResponse{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &authresp)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate user; status: %d; %s", status, err.Error())
-	} else if status != 201 {
+	if status != 201 {
 		return nil, fmt.Errorf("failed to authenticate user; status: %d", status)
 	}
 
@@ -85,7 +88,18 @@ func Authenticate(email, passwd string) (*AuthenticationResponse, error) {
 
 // CreateApplication on behalf of the given API token
 func CreateApplication(token string, params map[string]interface{}) (*Application, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("applications", params)
+	return CreateApplicationWithContext(context.Background(), token, params)
+}
+
+// CreateApplicationWithContext on behalf of the given API token
+func CreateApplicationWithContext(ctx context.Context, token string, params map[string]interface{}) (*Application, error) {
+	return InitIdentService(common.StringOrNil(token)).CreateApplication(ctx, params)
+}
+
+// CreateApplication on behalf of the given API token
+func (s *Service) CreateApplication(ctx context.Context, params map[string]interface{}) (*Application, error) {
+	app := &Application{}
+	status, err := s.PostInto(ctx, "applications", params, app)
 	if err != nil {
 		return nil, err
 	}
@@ -94,22 +108,23 @@ func CreateApplication(token string, params map[string]interface{}) (*Applicatio
 		return nil, fmt.Errorf("failed to create application; status: %v", status)
 	}
 
-	// FIXME...
-	app := &Application{}
-	appraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(appraw, &app)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create application; status: %v; %s", status, err.Error())
-	}
-
 	return app, nil
 }
 
 // UpdateApplication using the given API token, application id and params
 func UpdateApplication(token, applicationID string, params map[string]interface{}) error {
+	return UpdateApplicationWithContext(context.Background(), token, applicationID, params)
+}
+
+// UpdateApplicationWithContext using the given API token, application id and params
+func UpdateApplicationWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).UpdateApplication(ctx, applicationID, params)
+}
+
+// UpdateApplication using the given API token, application id and params
+func (s *Service) UpdateApplication(ctx context.Context, applicationID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("applications/%s", applicationID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Put(uri, params)
+	status, _, err := s.PutWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -123,7 +138,17 @@ func UpdateApplication(token, applicationID string, params map[string]interface{
 
 // DeleteApplication soft-deletes the application using the given API token
 func DeleteApplication(token, applicationID string) error {
-	err := UpdateApplication(token, applicationID, map[string]interface{}{
+	return DeleteApplicationWithContext(context.Background(), token, applicationID)
+}
+
+// DeleteApplicationWithContext soft-deletes the application using the given API token
+func DeleteApplicationWithContext(ctx context.Context, token, applicationID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteApplication(ctx, applicationID)
+}
+
+// DeleteApplication soft-deletes the application
+func (s *Service) DeleteApplication(ctx context.Context, applicationID string) error {
+	err := s.UpdateApplication(ctx, applicationID, map[string]interface{}{
 		"hidden": true,
 	})
 	if err != nil {
@@ -135,7 +160,18 @@ func DeleteApplication(token, applicationID string) error {
 
 // ListApplications retrieves a paginated list of applications scoped to the given API token
 func ListApplications(token string, params map[string]interface{}) ([]*Application, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("applications", params)
+	return ListApplicationsWithContext(context.Background(), token, params)
+}
+
+// ListApplicationsWithContext retrieves a paginated list of applications scoped to the given API token
+func ListApplicationsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Application, error) {
+	return InitIdentService(common.StringOrNil(token)).ListApplications(ctx, params)
+}
+
+// ListApplications retrieves a paginated list of applications scoped to the given API token
+func (s *Service) ListApplications(ctx context.Context, params map[string]interface{}) ([]*Application, error) {
+	apps := make([]*Application, 0)
+	status, err := s.GetInto(ctx, "applications", params, &apps)
 	if err != nil {
 		return nil, err
 	}
@@ -144,21 +180,34 @@ func ListApplications(token string, params map[string]interface{}) ([]*Applicati
 		return nil, fmt.Errorf("failed to list applications; status: %v", status)
 	}
 
-	apps := make([]*Application, 0)
-	for _, item := range resp.([]interface{}) {
-		app := &Application{}
-		appraw, _ := json.Marshal(item)
-		json.Unmarshal(appraw, &app)
-		apps = append(apps, app)
-	}
-
 	return apps, nil
 }
 
+// ListApplicationsPager returns an *api.Pager which walks all pages of the ListApplications results
+func ListApplicationsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationsPager(params)
+}
+
+// ListApplicationsPager returns an *api.Pager which walks all pages of the ListApplications results
+func (s *Service) ListApplicationsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("applications", params)
+}
+
 // GetApplicationDetails retrives application details for the given API token and application id
 func GetApplicationDetails(token, applicationID string, params map[string]interface{}) (*Application, error) {
+	return GetApplicationDetailsWithContext(context.Background(), token, applicationID, params)
+}
+
+// GetApplicationDetailsWithContext retrives application details for the given API token and application id
+func GetApplicationDetailsWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) (*Application, error) {
+	return InitIdentService(common.StringOrNil(token)).GetApplicationDetails(ctx, applicationID, params)
+}
+
+// GetApplicationDetails retrives application details for the given API token and application id
+func (s *Service) GetApplicationDetails(ctx context.Context, applicationID string, params map[string]interface{}) (*Application, error) {
 	uri := fmt.Sprintf("applications/%s", applicationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	app := &Application{}
+	status, err := s.GetInto(ctx, uri, params, app)
 	if err != nil {
 		return nil, err
 	}
@@ -167,22 +216,24 @@ func GetApplicationDetails(token, applicationID string, params map[string]interf
 		return nil, fmt.Errorf("failed to fetch application details; status: %v", status)
 	}
 
-	// FIXME...
-	app := &Application{}
-	appraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(appraw, &app)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch application details; status: %v; %s", status, err.Error())
-	}
-
 	return app, nil
 }
 
 // ListApplicationTokens retrieves a paginated list of application API tokens
 func ListApplicationTokens(token, applicationID string, params map[string]interface{}) ([]*Token, error) {
+	return ListApplicationTokensWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListApplicationTokensWithContext retrieves a paginated list of application API tokens
+func ListApplicationTokensWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*Token, error) {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationTokens(ctx, applicationID, params)
+}
+
+// ListApplicationTokens retrieves a paginated list of application API tokens
+func (s *Service) ListApplicationTokens(ctx context.Context, applicationID string, params map[string]interface{}) ([]*Token, error) {
 	uri := fmt.Sprintf("applications/%s/tokens", applicationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	tkns := make([]*Token, 0)
+	status, err := s.GetInto(ctx, uri, params, &tkns)
 	if err != nil {
 		return nil, err
 	}
@@ -191,21 +242,35 @@ func ListApplicationTokens(token, applicationID string, params map[string]interf
 		return nil, fmt.Errorf("failed to list application tokens; status: %v", status)
 	}
 
-	tkns := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tkn := &Token{}
-		tknraw, _ := json.Marshal(item)
-		json.Unmarshal(tknraw, &tkn)
-		tkns = append(tkns, tkn)
-	}
-
 	return tkns, nil
 }
 
+// ListApplicationTokensPager returns an *api.Pager which walks all pages of the ListApplicationTokens results
+func ListApplicationTokensPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationTokensPager(applicationID, params)
+}
+
+// ListApplicationTokensPager returns an *api.Pager which walks all pages of the ListApplicationTokens results
+func (s *Service) ListApplicationTokensPager(applicationID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("applications/%s/tokens", applicationID)
+	return s.Pager(uri, params)
+}
+
 // ListApplicationInvitations retrieves a paginated list of invitations scoped to the given API token
-func ListApplicationInvitations(token, applicationID string, params map[string]interface{}) ([]*User, error) {
+func ListApplicationInvitations(token, applicationID string, params map[string]interface{}) ([]*Invite, error) {
+	return ListApplicationInvitationsWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListApplicationInvitationsWithContext retrieves a paginated list of invitations scoped to the given API token
+func ListApplicationInvitationsWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*Invite, error) {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationInvitations(ctx, applicationID, params)
+}
+
+// ListApplicationInvitations retrieves a paginated list of invitations scoped to the given API token
+func (s *Service) ListApplicationInvitations(ctx context.Context, applicationID string, params map[string]interface{}) ([]*Invite, error) {
 	uri := fmt.Sprintf("applications/%s/invitations", applicationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	invites := make([]*Invite, 0)
+	status, err := s.GetInto(ctx, uri, params, &invites)
 	if err != nil {
 		return nil, err
 	}
@@ -214,21 +279,35 @@ func ListApplicationInvitations(token, applicationID string, params map[string]i
 		return nil, fmt.Errorf("failed to list application invitations; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
-	}
+	return invites, nil
+}
 
-	return users, nil
+// ListApplicationInvitationsPager returns an *api.Pager which walks all pages of the ListApplicationInvitations results
+func ListApplicationInvitationsPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationInvitationsPager(applicationID, params)
+}
+
+// ListApplicationInvitationsPager returns an *api.Pager which walks all pages of the ListApplicationInvitations results
+func (s *Service) ListApplicationInvitationsPager(applicationID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("applications/%s/invitations", applicationID)
+	return s.Pager(uri, params)
 }
 
 // ListApplicationOrganizations retrieves a paginated list of organizations scoped to the given API token
 func ListApplicationOrganizations(token, applicationID string, params map[string]interface{}) ([]*Organization, error) {
+	return ListApplicationOrganizationsWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListApplicationOrganizationsWithContext retrieves a paginated list of organizations scoped to the given API token
+func ListApplicationOrganizationsWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*Organization, error) {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationOrganizations(ctx, applicationID, params)
+}
+
+// ListApplicationOrganizations retrieves a paginated list of organizations scoped to the given API token
+func (s *Service) ListApplicationOrganizations(ctx context.Context, applicationID string, params map[string]interface{}) ([]*Organization, error) {
 	uri := fmt.Sprintf("applications/%s/organizations", applicationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	orgs := make([]*Organization, 0)
+	status, err := s.GetInto(ctx, uri, params, &orgs)
 	if err != nil {
 		return nil, err
 	}
@@ -237,21 +316,34 @@ func ListApplicationOrganizations(token, applicationID string, params map[string
 		return nil, fmt.Errorf("failed to list application organizations; status: %v", status)
 	}
 
-	orgs := make([]*Organization, 0)
-	for _, item := range resp.([]interface{}) {
-		org := &Organization{}
-		orgraw, _ := json.Marshal(item)
-		json.Unmarshal(orgraw, &org)
-		orgs = append(orgs, org)
-	}
-
 	return orgs, nil
 }
 
+// ListApplicationOrganizationsPager returns an *api.Pager which walks all pages of the ListApplicationOrganizations results
+func ListApplicationOrganizationsPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationOrganizationsPager(applicationID, params)
+}
+
+// ListApplicationOrganizationsPager returns an *api.Pager which walks all pages of the ListApplicationOrganizations results
+func (s *Service) ListApplicationOrganizationsPager(applicationID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("applications/%s/organizations", applicationID)
+	return s.Pager(uri, params)
+}
+
 // CreateApplicationOrganization associates an organization with an application
 func CreateApplicationOrganization(token, applicationID string, params map[string]interface{}) error {
+	return CreateApplicationOrganizationWithContext(context.Background(), token, applicationID, params)
+}
+
+// CreateApplicationOrganizationWithContext associates an organization with an application
+func CreateApplicationOrganizationWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).CreateApplicationOrganization(ctx, applicationID, params)
+}
+
+// CreateApplicationOrganization associates an organization with an application
+func (s *Service) CreateApplicationOrganization(ctx context.Context, applicationID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("applications/%s/organizations", applicationID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Post(uri, params)
+	status, _, err := s.PostWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -265,8 +357,18 @@ func CreateApplicationOrganization(token, applicationID string, params map[strin
 
 // DeleteApplicationOrganization disassociates an organization with an application
 func DeleteApplicationOrganization(token, applicationID, organizationID string) error {
+	return DeleteApplicationOrganizationWithContext(context.Background(), token, applicationID, organizationID)
+}
+
+// DeleteApplicationOrganizationWithContext disassociates an organization with an application
+func DeleteApplicationOrganizationWithContext(ctx context.Context, token, applicationID, organizationID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteApplicationOrganization(ctx, applicationID, organizationID)
+}
+
+// DeleteApplicationOrganization disassociates an organization with an application
+func (s *Service) DeleteApplicationOrganization(ctx context.Context, applicationID, organizationID string) error {
 	uri := fmt.Sprintf("applications/%s/organizations/%s", applicationID, organizationID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	status, _, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -280,8 +382,19 @@ func DeleteApplicationOrganization(token, applicationID, organizationID string)
 
 // ListApplicationUsers retrieves a paginated list of users scoped to the given API token
 func ListApplicationUsers(token, applicationID string, params map[string]interface{}) ([]*User, error) {
+	return ListApplicationUsersWithContext(context.Background(), token, applicationID, params)
+}
+
+// ListApplicationUsersWithContext retrieves a paginated list of users scoped to the given API token
+func ListApplicationUsersWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) ([]*User, error) {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationUsers(ctx, applicationID, params)
+}
+
+// ListApplicationUsers retrieves a paginated list of users scoped to the given API token
+func (s *Service) ListApplicationUsers(ctx context.Context, applicationID string, params map[string]interface{}) ([]*User, error) {
 	uri := fmt.Sprintf("applications/%s/users", applicationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	users := make([]*User, 0)
+	status, err := s.GetInto(ctx, uri, params, &users)
 	if err != nil {
 		return nil, err
 	}
@@ -290,21 +403,34 @@ func ListApplicationUsers(token, applicationID string, params map[string]interfa
 		return nil, fmt.Errorf("failed to list application users; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
-	}
-
 	return users, nil
 }
 
+// ListApplicationUsersPager returns an *api.Pager which walks all pages of the ListApplicationUsers results
+func ListApplicationUsersPager(token, applicationID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListApplicationUsersPager(applicationID, params)
+}
+
+// ListApplicationUsersPager returns an *api.Pager which walks all pages of the ListApplicationUsers results
+func (s *Service) ListApplicationUsersPager(applicationID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("applications/%s/users", applicationID)
+	return s.Pager(uri, params)
+}
+
 // CreateApplicationUser associates a user with an application
 func CreateApplicationUser(token, applicationID string, params map[string]interface{}) error {
+	return CreateApplicationUserWithContext(context.Background(), token, applicationID, params)
+}
+
+// CreateApplicationUserWithContext associates a user with an application
+func CreateApplicationUserWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).CreateApplicationUser(ctx, applicationID, params)
+}
+
+// CreateApplicationUser associates a user with an application
+func (s *Service) CreateApplicationUser(ctx context.Context, applicationID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("applications/%s/users", applicationID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Post(uri, params)
+	status, _, err := s.PostWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -316,10 +442,45 @@ func CreateApplicationUser(token, applicationID string, params map[string]interf
 	return nil
 }
 
+// UpdateApplicationUser updates an associated application user
+func UpdateApplicationUser(token, applicationID, userID string, params map[string]interface{}) error {
+	return UpdateApplicationUserWithContext(context.Background(), token, applicationID, userID, params)
+}
+
+// UpdateApplicationUserWithContext updates an associated application user
+func UpdateApplicationUserWithContext(ctx context.Context, token, applicationID, userID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).UpdateApplicationUser(ctx, applicationID, userID, params)
+}
+
+// UpdateApplicationUser updates an associated application user
+func (s *Service) UpdateApplicationUser(ctx context.Context, applicationID, userID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("applications/%s/users/%s", applicationID, userID)
+	status, _, err := s.PutWithContext(ctx, uri, params)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to update associated application user; status: %v", status)
+	}
+
+	return nil
+}
+
 // DeleteApplicationUser disassociates a user with an application
 func DeleteApplicationUser(token, applicationID, userID string) error {
+	return DeleteApplicationUserWithContext(context.Background(), token, applicationID, userID)
+}
+
+// DeleteApplicationUserWithContext disassociates a user with an application
+func DeleteApplicationUserWithContext(ctx context.Context, token, applicationID, userID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteApplicationUser(ctx, applicationID, userID)
+}
+
+// DeleteApplicationUser disassociates a user with an application
+func (s *Service) DeleteApplicationUser(ctx context.Context, applicationID, userID string) error {
 	uri := fmt.Sprintf("applications/%s/users/%s", applicationID, userID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	status, _, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -333,27 +494,61 @@ func DeleteApplicationUser(token, applicationID, userID string) error {
 
 // CreateApplicationToken creates a new API token for the given application ID.
 func CreateApplicationToken(token, applicationID string, params map[string]interface{}) (*Token, error) {
+	return CreateApplicationTokenWithContext(context.Background(), token, applicationID, params)
+}
+
+// CreateApplicationTokenWithContext creates a new API token for the given application ID.
+func CreateApplicationTokenWithContext(ctx context.Context, token, applicationID string, params map[string]interface{}) (*Token, error) {
+	return InitIdentService(common.StringOrNil(token)).CreateApplicationToken(ctx, applicationID, params)
+}
+
+// CreateApplicationToken creates a new API token for the given application ID.
+func (s *Service) CreateApplicationToken(ctx context.Context, applicationID string, params map[string]interface{}) (*Token, error) {
 	params["application_id"] = applicationID
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("tokens", params)
+	tkn := &Token{}
+	_, err := s.PostInto(ctx, "tokens", params, tkn)
 	if err != nil {
 		return nil, err
 	}
 
-	// FIXME...
-	tkn := &Token{}
-	tknraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(tknraw, &tkn)
+	return tkn, nil
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to authorize application token; status: %v; %s", status, err.Error())
+// CreateOrganizationToken creates a new API token for the given organization ID.
+func CreateOrganizationToken(token, organizationID string, params map[string]interface{}) (*Token, error) {
+	return CreateOrganizationTokenWithContext(context.Background(), token, organizationID, params)
+}
+
+// CreateOrganizationTokenWithContext creates a new API token for the given organization ID.
+func CreateOrganizationTokenWithContext(ctx context.Context, token, organizationID string, params map[string]interface{}) (*Token, error) {
+	return InitIdentService(common.StringOrNil(token)).CreateOrganizationToken(ctx, organizationID, params)
+}
+
+// CreateOrganizationToken creates a new API token for the given organization ID.
+func (s *Service) CreateOrganizationToken(ctx context.Context, organizationID string, params map[string]interface{}) (*Token, error) {
+	tokenParams := map[string]interface{}{}
+	for key, val := range params {
+		tokenParams[key] = val
 	}
+	tokenParams["organization_id"] = organizationID
 
-	return tkn, nil
+	return s.CreateToken(ctx, tokenParams)
 }
 
 // ListOrganizations retrieves a paginated list of organizations scoped to the given API token
 func ListOrganizations(token string, params map[string]interface{}) ([]*Organization, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("organizations", params)
+	return ListOrganizationsWithContext(context.Background(), token, params)
+}
+
+// ListOrganizationsWithContext retrieves a paginated list of organizations scoped to the given API token
+func ListOrganizationsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Organization, error) {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizations(ctx, params)
+}
+
+// ListOrganizations retrieves a paginated list of organizations scoped to the given API token
+func (s *Service) ListOrganizations(ctx context.Context, params map[string]interface{}) ([]*Organization, error) {
+	orgs := make([]*Organization, 0)
+	status, err := s.GetInto(ctx, "organizations", params, &orgs)
 	if err != nil {
 		return nil, err
 	}
@@ -362,20 +557,33 @@ func ListOrganizations(token string, params map[string]interface{}) ([]*Organiza
 		return nil, fmt.Errorf("failed to list organizations; status: %v", status)
 	}
 
-	orgs := make([]*Organization, 0)
-	for _, item := range resp.([]interface{}) {
-		org := &Organization{}
-		orgraw, _ := json.Marshal(item)
-		json.Unmarshal(orgraw, &org)
-		orgs = append(orgs, org)
-	}
-
 	return orgs, nil
 }
 
+// ListOrganizationsPager returns an *api.Pager which walks all pages of the ListOrganizations results
+func ListOrganizationsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationsPager(params)
+}
+
+// ListOrganizationsPager returns an *api.Pager which walks all pages of the ListOrganizations results
+func (s *Service) ListOrganizationsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("organizations", params)
+}
+
 // CreateToken creates a new API token.
 func CreateToken(token string, params map[string]interface{}) (*Token, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("tokens", params)
+	return CreateTokenWithContext(context.Background(), token, params)
+}
+
+// CreateTokenWithContext creates a new API token.
+func CreateTokenWithContext(ctx context.Context, token string, params map[string]interface{}) (*Token, error) {
+	return InitIdentService(common.StringOrNil(token)).CreateToken(ctx, params)
+}
+
+// CreateToken creates a new API token.
+func (s *Service) CreateToken(ctx context.Context, params map[string]interface{}) (*Token, error) {
+	tkn := &Token{}
+	status, err := s.PostInto(ctx, "tokens", params, tkn)
 	if err != nil {
 		return nil, err
 	}
@@ -384,21 +592,23 @@ func CreateToken(token string, params map[string]interface{}) (*Token, error) {
 		return nil, fmt.Errorf("failed to authorize token; status: %v", status)
 	}
 
-	// FIXME...
-	tkn := &Token{}
-	tknraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(tknraw, &tkn)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to authorize tokens; status: %v; %s", status, err.Error())
-	}
-
 	return tkn, nil
 }
 
 // ListTokens retrieves a paginated list of API tokens scoped to the given API token
 func ListTokens(token string, params map[string]interface{}) ([]*Token, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("tokens", params)
+	return ListTokensWithContext(context.Background(), token, params)
+}
+
+// ListTokensWithContext retrieves a paginated list of API tokens scoped to the given API token
+func ListTokensWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Token, error) {
+	return InitIdentService(common.StringOrNil(token)).ListTokens(ctx, params)
+}
+
+// ListTokens retrieves a paginated list of API tokens scoped to the given API token
+func (s *Service) ListTokens(ctx context.Context, params map[string]interface{}) ([]*Token, error) {
+	tkns := make([]*Token, 0)
+	status, err := s.GetInto(ctx, "tokens", params, &tkns)
 	if err != nil {
 		return nil, err
 	}
@@ -407,21 +617,34 @@ func ListTokens(token string, params map[string]interface{}) ([]*Token, error) {
 		return nil, fmt.Errorf("failed to list application tokens; status: %v", status)
 	}
 
-	tkns := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tkn := &Token{}
-		tknraw, _ := json.Marshal(item)
-		json.Unmarshal(tknraw, &tkn)
-		tkns = append(tkns, tkn)
-	}
-
 	return tkns, nil
 }
 
+// ListTokensPager returns an *api.Pager which walks all pages of the ListTokens results
+func ListTokensPager(token string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListTokensPager(params)
+}
+
+// ListTokensPager returns an *api.Pager which walks all pages of the ListTokens results
+func (s *Service) ListTokensPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("tokens", params)
+}
+
 // GetTokenDetails retrieves details for the given API token id
 func GetTokenDetails(token, tokenID string, params map[string]interface{}) (*Token, error) {
+	return GetTokenDetailsWithContext(context.Background(), token, tokenID, params)
+}
+
+// GetTokenDetailsWithContext retrieves details for the given API token id
+func GetTokenDetailsWithContext(ctx context.Context, token, tokenID string, params map[string]interface{}) (*Token, error) {
+	return InitIdentService(common.StringOrNil(token)).GetTokenDetails(ctx, tokenID, params)
+}
+
+// GetTokenDetails retrieves details for the given API token id
+func (s *Service) GetTokenDetails(ctx context.Context, tokenID string, params map[string]interface{}) (*Token, error) {
 	uri := fmt.Sprintf("tokens/%s", tokenID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	tkn := &Token{}
+	status, err := s.GetInto(ctx, uri, params, tkn)
 	if err != nil {
 		return nil, err
 	}
@@ -430,22 +653,23 @@ func GetTokenDetails(token, tokenID string, params map[string]interface{}) (*Tok
 		return nil, fmt.Errorf("failed to fetch token details; status: %v", status)
 	}
 
-	// FIXME...
-	tkn := &Token{}
-	tknraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(tknraw, &tkn)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch token details; status: %v; %s", status, err.Error())
-	}
-
 	return tkn, nil
 }
 
 // DeleteToken removes a previously authorized API token, effectively deauthorizing future calls using the token
 func DeleteToken(token, tokenID string) error {
+	return DeleteTokenWithContext(context.Background(), token, tokenID)
+}
+
+// DeleteTokenWithContext removes a previously authorized API token, effectively deauthorizing future calls using the token
+func DeleteTokenWithContext(ctx context.Context, token, tokenID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteToken(ctx, tokenID)
+}
+
+// DeleteToken removes a previously authorized API token, effectively deauthorizing future calls using the token
+func (s *Service) DeleteToken(ctx context.Context, tokenID string) error {
 	uri := fmt.Sprintf("tokens/%s", tokenID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	status, _, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -459,7 +683,18 @@ func DeleteToken(token, tokenID string) error {
 
 // CreateOrganization creates a new organization
 func CreateOrganization(token string, params map[string]interface{}) (*Organization, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("organizations", params)
+	return CreateOrganizationWithContext(context.Background(), token, params)
+}
+
+// CreateOrganizationWithContext creates a new organization
+func CreateOrganizationWithContext(ctx context.Context, token string, params map[string]interface{}) (*Organization, error) {
+	return InitIdentService(common.StringOrNil(token)).CreateOrganization(ctx, params)
+}
+
+// CreateOrganization creates a new organization
+func (s *Service) CreateOrganization(ctx context.Context, params map[string]interface{}) (*Organization, error) {
+	org := &Organization{}
+	status, err := s.PostInto(ctx, "organizations", params, org)
 	if err != nil {
 		return nil, err
 	}
@@ -468,22 +703,24 @@ func CreateOrganization(token string, params map[string]interface{}) (*Organizat
 		return nil, fmt.Errorf("failed to create organization; status: %v", status)
 	}
 
-	// FIXME...
-	org := &Organization{}
-	orgraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(orgraw, &org)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create organization; status: %v; %s", status, err.Error())
-	}
-
 	return org, nil
 }
 
 // GetOrganizationDetails retrieves details for the given organization
 func GetOrganizationDetails(token, organizationID string, params map[string]interface{}) (*Organization, error) {
+	return GetOrganizationDetailsWithContext(context.Background(), token, organizationID, params)
+}
+
+// GetOrganizationDetailsWithContext retrieves details for the given organization
+func GetOrganizationDetailsWithContext(ctx context.Context, token, organizationID string, params map[string]interface{}) (*Organization, error) {
+	return InitIdentService(common.StringOrNil(token)).GetOrganizationDetails(ctx, organizationID, params)
+}
+
+// GetOrganizationDetails retrieves details for the given organization
+func (s *Service) GetOrganizationDetails(ctx context.Context, organizationID string, params map[string]interface{}) (*Organization, error) {
 	uri := fmt.Sprintf("organizations/%s", organizationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	org := &Organization{}
+	status, err := s.GetInto(ctx, uri, params, org)
 	if err != nil {
 		return nil, err
 	}
@@ -492,22 +729,23 @@ func GetOrganizationDetails(token, organizationID string, params map[string]inte
 		return nil, fmt.Errorf("failed to fetch organization; status: %v", status)
 	}
 
-	// FIXME...
-	org := &Organization{}
-	orgraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(orgraw, &org)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch organization details; status: %v; %s", status, err.Error())
-	}
-
 	return org, nil
 }
 
 // UpdateOrganization updates an organization
 func UpdateOrganization(token, organizationID string, params map[string]interface{}) error {
+	return UpdateOrganizationWithContext(context.Background(), token, organizationID, params)
+}
+
+// UpdateOrganizationWithContext updates an organization
+func UpdateOrganizationWithContext(ctx context.Context, token, organizationID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).UpdateOrganization(ctx, organizationID, params)
+}
+
+// UpdateOrganization updates an organization
+func (s *Service) UpdateOrganization(ctx context.Context, organizationID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("organizations/%s", organizationID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Put(uri, params)
+	status, _, err := s.PutWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -519,9 +757,81 @@ func UpdateOrganization(token, organizationID string, params map[string]interfac
 	return nil
 }
 
+// DeleteOrganization removes an organization
+func DeleteOrganization(token, organizationID string) error {
+	return DeleteOrganizationWithContext(context.Background(), token, organizationID)
+}
+
+// DeleteOrganizationWithContext removes an organization
+func DeleteOrganizationWithContext(ctx context.Context, token, organizationID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteOrganization(ctx, organizationID)
+}
+
+// DeleteOrganization removes an organization
+func (s *Service) DeleteOrganization(ctx context.Context, organizationID string) error {
+	uri := fmt.Sprintf("organizations/%s", organizationID)
+	status, _, err := s.DeleteWithContext(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete organization; status: %v", status)
+	}
+
+	return nil
+}
+
+// ListOrganizationApplications retrieves a paginated list of applications associated with an organization
+func ListOrganizationApplications(token, organizationID string, params map[string]interface{}) ([]*Application, error) {
+	return ListOrganizationApplicationsWithContext(context.Background(), token, organizationID, params)
+}
+
+// ListOrganizationApplicationsWithContext retrieves a paginated list of applications associated with an organization
+func ListOrganizationApplicationsWithContext(ctx context.Context, token, organizationID string, params map[string]interface{}) ([]*Application, error) {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationApplications(ctx, organizationID, params)
+}
+
+// ListOrganizationApplications retrieves a paginated list of applications associated with an organization
+func (s *Service) ListOrganizationApplications(ctx context.Context, organizationID string, params map[string]interface{}) ([]*Application, error) {
+	uri := fmt.Sprintf("organizations/%s/applications", organizationID)
+	apps := make([]*Application, 0)
+	status, err := s.GetInto(ctx, uri, params, &apps)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list organization applications; status: %v", status)
+	}
+
+	return apps, nil
+}
+
+// ListOrganizationApplicationsPager returns an *api.Pager which walks all pages of the ListOrganizationApplications results
+func ListOrganizationApplicationsPager(token, organizationID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationApplicationsPager(organizationID, params)
+}
+
+// ListOrganizationApplicationsPager returns an *api.Pager which walks all pages of the ListOrganizationApplications results
+func (s *Service) ListOrganizationApplicationsPager(organizationID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("organizations/%s/applications", organizationID)
+	return s.Pager(uri, params)
+}
+
 // CreateInvitation creates a user invitation
 func CreateInvitation(token string, params map[string]interface{}) error {
-	status, _, err := InitIdentService(common.StringOrNil(token)).Post("invitations", params)
+	return CreateInvitationWithContext(context.Background(), token, params)
+}
+
+// CreateInvitationWithContext creates a user invitation
+func CreateInvitationWithContext(ctx context.Context, token string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).CreateInvitation(ctx, params)
+}
+
+// CreateInvitation creates a user invitation
+func (s *Service) CreateInvitation(ctx context.Context, params map[string]interface{}) error {
+	status, _, err := s.PostWithContext(ctx, "invitations", params)
 	if err != nil {
 		return err
 	}
@@ -533,20 +843,76 @@ func CreateInvitation(token string, params map[string]interface{}) error {
 	return nil
 }
 
-// CreateUser creates a new user for which API tokens and managed signing identities can be authorized
-func CreateUser(token string, params map[string]interface{}) (*User, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("users", params)
+// GetInvitation retrieves details for the given invitation id
+func GetInvitation(token, invitationID string) (*Invite, error) {
+	return GetInvitationWithContext(context.Background(), token, invitationID)
+}
+
+// GetInvitationWithContext retrieves details for the given invitation id
+func GetInvitationWithContext(ctx context.Context, token, invitationID string) (*Invite, error) {
+	return InitIdentService(common.StringOrNil(token)).GetInvitation(ctx, invitationID)
+}
+
+// GetInvitation retrieves details for the given invitation id
+func (s *Service) GetInvitation(ctx context.Context, invitationID string) (*Invite, error) {
+	uri := fmt.Sprintf("invitations/%s", invitationID)
+	invite := &Invite{}
+	status, err := s.GetInto(ctx, uri, map[string]interface{}{}, invite)
 	if err != nil {
 		return nil, err
 	}
 
-	// FIXME...
-	usr := &User{}
-	usrraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(usrraw, &usr)
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch invitation; status: %v", status)
+	}
 
+	return invite, nil
+}
+
+// AcceptInvitation accepts the given invitation on behalf of the user authorized by the given API
+// token, associating the user with the invited organization and application
+func AcceptInvitation(token, invitationID string) error {
+	return AcceptInvitationWithContext(context.Background(), token, invitationID)
+}
+
+// AcceptInvitationWithContext accepts the given invitation on behalf of the user authorized by the
+// given API token, associating the user with the invited organization and application
+func AcceptInvitationWithContext(ctx context.Context, token, invitationID string) error {
+	return InitIdentService(common.StringOrNil(token)).AcceptInvitation(ctx, invitationID)
+}
+
+// AcceptInvitation accepts the given invitation on behalf of the authorized user, associating the
+// user with the invited organization and application
+func (s *Service) AcceptInvitation(ctx context.Context, invitationID string) error {
+	uri := fmt.Sprintf("invitations/%s/accept", invitationID)
+	status, _, err := s.PostWithContext(ctx, uri, map[string]interface{}{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user; status: %v; %s", status, err.Error())
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to accept invitation; status: %v", status)
+	}
+
+	return nil
+}
+
+// CreateUser creates a new user for which API tokens and managed signing identities can be authorized
+func CreateUser(token string, params map[string]interface{}) (*User, error) {
+	return CreateUserWithContext(context.Background(), token, params)
+}
+
+// CreateUserWithContext creates a new user for which API tokens and managed signing identities can be authorized
+func CreateUserWithContext(ctx context.Context, token string, params map[string]interface{}) (*User, error) {
+	return InitIdentService(common.StringOrNil(token)).CreateUser(ctx, params)
+}
+
+// CreateUser creates a new user for which API tokens and managed signing identities can be authorized
+func (s *Service) CreateUser(ctx context.Context, params map[string]interface{}) (*User, error) {
+	usr := &User{}
+	_, err := s.PostInto(ctx, "users", params, usr)
+	if err != nil {
+		return nil, err
 	}
 
 	return usr, nil
@@ -554,8 +920,19 @@ func CreateUser(token string, params map[string]interface{}) (*User, error) {
 
 // ListOrganizationUsers retrieves a paginated list of users scoped to an organization
 func ListOrganizationUsers(token, orgID string, params map[string]interface{}) ([]*User, error) {
+	return ListOrganizationUsersWithContext(context.Background(), token, orgID, params)
+}
+
+// ListOrganizationUsersWithContext retrieves a paginated list of users scoped to an organization
+func ListOrganizationUsersWithContext(ctx context.Context, token, orgID string, params map[string]interface{}) ([]*User, error) {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationUsers(ctx, orgID, params)
+}
+
+// ListOrganizationUsers retrieves a paginated list of users scoped to an organization
+func (s *Service) ListOrganizationUsers(ctx context.Context, orgID string, params map[string]interface{}) ([]*User, error) {
 	uri := fmt.Sprintf("organizations/%s/users", orgID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	users := make([]*User, 0)
+	status, err := s.GetInto(ctx, uri, params, &users)
 	if err != nil {
 		return nil, err
 	}
@@ -564,21 +941,34 @@ func ListOrganizationUsers(token, orgID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list users; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
-	}
-
 	return users, nil
 }
 
+// ListOrganizationUsersPager returns an *api.Pager which walks all pages of the ListOrganizationUsers results
+func ListOrganizationUsersPager(token, orgID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationUsersPager(orgID, params)
+}
+
+// ListOrganizationUsersPager returns an *api.Pager which walks all pages of the ListOrganizationUsers results
+func (s *Service) ListOrganizationUsersPager(orgID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("organizations/%s/users", orgID)
+	return s.Pager(uri, params)
+}
+
 // CreateOrganizationUser associates a user with an organization
 func CreateOrganizationUser(token, orgID string, params map[string]interface{}) error {
+	return CreateOrganizationUserWithContext(context.Background(), token, orgID, params)
+}
+
+// CreateOrganizationUserWithContext associates a user with an organization
+func CreateOrganizationUserWithContext(ctx context.Context, token, orgID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).CreateOrganizationUser(ctx, orgID, params)
+}
+
+// CreateOrganizationUser associates a user with an organization
+func (s *Service) CreateOrganizationUser(ctx context.Context, orgID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("organizations/%s/users", orgID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Post(uri, params)
+	status, _, err := s.PostWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -592,8 +982,18 @@ func CreateOrganizationUser(token, orgID string, params map[string]interface{})
 
 // UpdateOrganizationUser updates an associated organization user=
 func UpdateOrganizationUser(token, orgID, userID string, params map[string]interface{}) error {
+	return UpdateOrganizationUserWithContext(context.Background(), token, orgID, userID, params)
+}
+
+// UpdateOrganizationUserWithContext updates an associated organization user=
+func UpdateOrganizationUserWithContext(ctx context.Context, token, orgID, userID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).UpdateOrganizationUser(ctx, orgID, userID, params)
+}
+
+// UpdateOrganizationUser updates an associated organization user=
+func (s *Service) UpdateOrganizationUser(ctx context.Context, orgID, userID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("organizations/%s/users/%s", orgID, userID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Put(uri, params)
+	status, _, err := s.PutWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -607,8 +1007,18 @@ func UpdateOrganizationUser(token, orgID, userID string, params map[string]inter
 
 // DeleteOrganizationUser disassociates a user with an organization
 func DeleteOrganizationUser(token, orgID, userID string) error {
+	return DeleteOrganizationUserWithContext(context.Background(), token, orgID, userID)
+}
+
+// DeleteOrganizationUserWithContext disassociates a user with an organization
+func DeleteOrganizationUserWithContext(ctx context.Context, token, orgID, userID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteOrganizationUser(ctx, orgID, userID)
+}
+
+// DeleteOrganizationUser disassociates a user with an organization
+func (s *Service) DeleteOrganizationUser(ctx context.Context, orgID, userID string) error {
 	uri := fmt.Sprintf("organizations/%s/users/%s", orgID, userID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	status, _, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -621,9 +1031,20 @@ func DeleteOrganizationUser(token, orgID, userID string) error {
 }
 
 // ListOrganizationInvitations retrieves a paginated list of organization invitations scoped to the given API token
-func ListOrganizationInvitations(token, organizationID string, params map[string]interface{}) ([]*User, error) {
+func ListOrganizationInvitations(token, organizationID string, params map[string]interface{}) ([]*Invite, error) {
+	return ListOrganizationInvitationsWithContext(context.Background(), token, organizationID, params)
+}
+
+// ListOrganizationInvitationsWithContext retrieves a paginated list of organization invitations scoped to the given API token
+func ListOrganizationInvitationsWithContext(ctx context.Context, token, organizationID string, params map[string]interface{}) ([]*Invite, error) {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationInvitations(ctx, organizationID, params)
+}
+
+// ListOrganizationInvitations retrieves a paginated list of organization invitations scoped to the given API token
+func (s *Service) ListOrganizationInvitations(ctx context.Context, organizationID string, params map[string]interface{}) ([]*Invite, error) {
 	uri := fmt.Sprintf("organizations/%s/invitations", organizationID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	invites := make([]*Invite, 0)
+	status, err := s.GetInto(ctx, uri, params, &invites)
 	if err != nil {
 		return nil, err
 	}
@@ -632,20 +1053,34 @@ func ListOrganizationInvitations(token, organizationID string, params map[string
 		return nil, fmt.Errorf("failed to list organization invitations; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
-	}
+	return invites, nil
+}
 
-	return users, nil
+// ListOrganizationInvitationsPager returns an *api.Pager which walks all pages of the ListOrganizationInvitations results
+func ListOrganizationInvitationsPager(token, organizationID string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListOrganizationInvitationsPager(organizationID, params)
+}
+
+// ListOrganizationInvitationsPager returns an *api.Pager which walks all pages of the ListOrganizationInvitations results
+func (s *Service) ListOrganizationInvitationsPager(organizationID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("organizations/%s/invitations", organizationID)
+	return s.Pager(uri, params)
 }
 
 // ListUsers retrieves a paginated list of users scoped to the given API token
 func ListUsers(token string, params map[string]interface{}) ([]*User, error) {
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("users", params)
+	return ListUsersWithContext(context.Background(), token, params)
+}
+
+// ListUsersWithContext retrieves a paginated list of users scoped to the given API token
+func ListUsersWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*User, error) {
+	return InitIdentService(common.StringOrNil(token)).ListUsers(ctx, params)
+}
+
+// ListUsers retrieves a paginated list of users scoped to the given API token
+func (s *Service) ListUsers(ctx context.Context, params map[string]interface{}) ([]*User, error) {
+	users := make([]*User, 0)
+	status, err := s.GetInto(ctx, "users", params, &users)
 	if err != nil {
 		return nil, err
 	}
@@ -654,32 +1089,36 @@ func ListUsers(token string, params map[string]interface{}) ([]*User, error) {
 		return nil, fmt.Errorf("failed to list users; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
-	}
-
 	return users, nil
 }
 
+// ListUsersPager returns an *api.Pager which walks all pages of the ListUsers results
+func ListUsersPager(token string, params map[string]interface{}) *api.Pager {
+	return InitIdentService(common.StringOrNil(token)).ListUsersPager(params)
+}
+
+// ListUsersPager returns an *api.Pager which walks all pages of the ListUsers results
+func (s *Service) ListUsersPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("users", params)
+}
+
 // GetUserDetails retrieves details for the given user id
 func GetUserDetails(token, userID string, params map[string]interface{}) (*User, error) {
-	uri := fmt.Sprintf("users/%s", userID)
-	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
-	if err != nil {
-		return nil, err
-	}
+	return GetUserDetailsWithContext(context.Background(), token, userID, params)
+}
 
-	// FIXME...
-	usr := &User{}
-	usrraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(usrraw, &usr)
+// GetUserDetailsWithContext retrieves details for the given user id
+func GetUserDetailsWithContext(ctx context.Context, token, userID string, params map[string]interface{}) (*User, error) {
+	return InitIdentService(common.StringOrNil(token)).GetUserDetails(ctx, userID, params)
+}
 
+// GetUserDetails retrieves details for the given user id
+func (s *Service) GetUserDetails(ctx context.Context, userID string, params map[string]interface{}) (*User, error) {
+	uri := fmt.Sprintf("users/%s", userID)
+	usr := &User{}
+	_, err := s.GetInto(ctx, uri, params, usr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch user details; status: %v; %s", status, err.Error())
+		return nil, err
 	}
 
 	return usr, nil
@@ -687,8 +1126,18 @@ func GetUserDetails(token, userID string, params map[string]interface{}) (*User,
 
 // UpdateUser updates an existing user
 func UpdateUser(token, userID string, params map[string]interface{}) error {
+	return UpdateUserWithContext(context.Background(), token, userID, params)
+}
+
+// UpdateUserWithContext updates an existing user
+func UpdateUserWithContext(ctx context.Context, token, userID string, params map[string]interface{}) error {
+	return InitIdentService(common.StringOrNil(token)).UpdateUser(ctx, userID, params)
+}
+
+// UpdateUser updates an existing user
+func (s *Service) UpdateUser(ctx context.Context, userID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("users/%s", userID)
-	status, _, err := InitIdentService(common.StringOrNil(token)).Put(uri, params)
+	status, _, err := s.PutWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -700,8 +1149,43 @@ func UpdateUser(token, userID string, params map[string]interface{}) error {
 	return nil
 }
 
+// DeleteUser removes an existing user
+func DeleteUser(token, userID string) error {
+	return DeleteUserWithContext(context.Background(), token, userID)
+}
+
+// DeleteUserWithContext removes an existing user
+func DeleteUserWithContext(ctx context.Context, token, userID string) error {
+	return InitIdentService(common.StringOrNil(token)).DeleteUser(ctx, userID)
+}
+
+// DeleteUser removes an existing user
+func (s *Service) DeleteUser(ctx context.Context, userID string) error {
+	uri := fmt.Sprintf("users/%s", userID)
+	status, _, err := s.DeleteWithContext(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete user; status: %v", status)
+	}
+
+	return nil
+}
+
 // RequestPasswordReset initiates a password reset request
 func RequestPasswordReset(token, applicationID *string, email string) error {
+	return RequestPasswordResetWithContext(context.Background(), token, applicationID, email)
+}
+
+// RequestPasswordResetWithContext initiates a password reset request
+func RequestPasswordResetWithContext(ctx context.Context, token, applicationID *string, email string) error {
+	return InitIdentService(token).RequestPasswordReset(ctx, applicationID, email)
+}
+
+// RequestPasswordReset initiates a password reset request
+func (s *Service) RequestPasswordReset(ctx context.Context, applicationID *string, email string) error {
 	params := map[string]interface{}{
 		"email": email,
 	}
@@ -709,9 +1193,9 @@ func RequestPasswordReset(token, applicationID *string, email string) error {
 		params["application_id"] = applicationID
 	}
 
-	status, _, err := InitIdentService(token).Post("users/reset_password", params)
+	status, _, err := s.PostWithContext(ctx, "users/reset_password", params)
 	if err != nil {
-		return fmt.Errorf("failed to request password reset; status: %v; %s", status, err.Error())
+		return fmt.Errorf("failed to request password reset; status: %v; %w", status, err)
 	}
 
 	if status != 204 {
@@ -723,12 +1207,22 @@ func RequestPasswordReset(token, applicationID *string, email string) error {
 
 // ResetPassword completes a previously-requested password reset operation for a user
 func ResetPassword(token *string, resetPasswordToken, passwd string) error {
+	return ResetPasswordWithContext(context.Background(), token, resetPasswordToken, passwd)
+}
+
+// ResetPasswordWithContext completes a previously-requested password reset operation for a user
+func ResetPasswordWithContext(ctx context.Context, token *string, resetPasswordToken, passwd string) error {
+	return InitIdentService(token).ResetPassword(ctx, resetPasswordToken, passwd)
+}
+
+// ResetPassword completes a previously-requested password reset operation for a user
+func (s *Service) ResetPassword(ctx context.Context, resetPasswordToken, passwd string) error {
 	uri := fmt.Sprintf("users/reset_password/%s", resetPasswordToken)
-	status, _, err := InitIdentService(token).Post(uri, map[string]interface{}{
+	status, _, err := s.PostWithContext(ctx, uri, map[string]interface{}{
 		"password": passwd,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to reset password; status: %v; %s", status, err.Error())
+		return fmt.Errorf("failed to reset password; status: %v; %w", status, err)
 	}
 	if status != 204 {
 		return fmt.Errorf("failed to reset password; status: %v", status)
@@ -739,27 +1233,19 @@ func ResetPassword(token *string, resetPasswordToken, passwd string) error {
 
 // Status returns the status of the endpoint
 func Status() error {
-	host := defaultIdentHost
-	if os.Getenv("IDENT_API_HOST") != "" {
-		host = os.Getenv("IDENT_API_HOST")
-	}
-
-	scheme := defaultIdentScheme
-	if os.Getenv("IDENT_API_SCHEME") != "" {
-		scheme = os.Getenv("IDENT_API_SCHEME")
-	}
+	return StatusWithContext(context.Background())
+}
 
-	service := &Service{
-		api.Client{
-			Host:   host,
-			Path:   "",
-			Scheme: scheme,
-		},
-	}
+// StatusWithContext returns the status of the endpoint
+func StatusWithContext(ctx context.Context) error {
+	return InitIdentService(nil).Status(ctx)
+}
 
-	status, _, err := service.Get("status", map[string]interface{}{})
+// Status returns the status of the endpoint
+func (s *Service) Status(ctx context.Context) error {
+	status, _, err := s.rootClient().GetWithContext(ctx, "status", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to fetch status; %s", err.Error())
+		return fmt.Errorf("failed to fetch status; %w", err)
 	}
 
 	if status != 200 {
@@ -771,40 +1257,33 @@ func Status() error {
 
 // GetJWKs returns the set of keys containing the public keys used to verify JWTs
 func GetJWKs() ([]*JSONWebKey, error) {
-	host := defaultIdentHost
-	if os.Getenv("IDENT_API_HOST") != "" {
-		host = os.Getenv("IDENT_API_HOST")
-	}
-
-	scheme := defaultIdentScheme
-	if os.Getenv("IDENT_API_SCHEME") != "" {
-		scheme = os.Getenv("IDENT_API_SCHEME")
-	}
+	return GetJWKsWithContext(context.Background())
+}
 
-	service := &Service{
-		api.Client{
-			Host:   host,
-			Path:   "",
-			Scheme: scheme,
-		},
-	}
+// GetJWKsWithContext returns the set of keys containing the public keys used to verify JWTs
+func GetJWKsWithContext(ctx context.Context) ([]*JSONWebKey, error) {
+	return InitIdentService(nil).GetJWKs(ctx)
+}
 
-	status, resp, err := service.Get(".well-known/keys", map[string]interface{}{})
+// GetJWKs returns the set of keys containing the public keys used to verify JWTs
+func (s *Service) GetJWKs(ctx context.Context) ([]*JSONWebKey, error) {
+	keys := make([]*JSONWebKey, 0)
+	status, err := s.rootClient().GetInto(ctx, ".well-known/keys", map[string]interface{}{}, &keys)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch well-known JWKs; %s", err.Error())
+		return nil, fmt.Errorf("failed to fetch well-known JWKs; %w", err)
 	}
 
 	if status != 200 {
 		return nil, fmt.Errorf("well-known JWKs endpoint returned %d status code", status)
 	}
 
-	keys := make([]*JSONWebKey, 0)
-	for _, item := range resp.([]interface{}) {
-		key := &JSONWebKey{}
-		keyraw, _ := json.Marshal(item)
-		json.Unmarshal(keyraw, &key)
-		keys = append(keys, key)
-	}
-
 	return keys, nil
 }
+
+// rootClient returns a copy of the client which addresses the root of the ident host,
+// where the status and well-known endpoints are served
+func (s *Service) rootClient() *api.Client {
+	client := s.Client
+	client.Path = ""
+	return &client
+}
@@ -0,0 +1,114 @@
+package ident_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/api/fake"
+	"github.com/provideplatform/provide-go/api/ident"
+)
+
+func TestOnboarding(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	token := srv.Token()
+	org, err := ident.CreateOrganization(token, map[string]interface{}{"name": "org"})
+	if err != nil {
+		t.Fatalf("failed to create organization; %s", err.Error())
+	}
+	orgID := org.ID.String()
+	app, err := ident.CreateApplication(token, map[string]interface{}{"name": "app"})
+	if err != nil {
+		t.Fatalf("failed to create application; %s", err.Error())
+	}
+	appID := app.ID.String()
+
+	err = ident.CreateApplicationOrganization(token, appID, map[string]interface{}{"organization_id": orgID})
+	if err != nil {
+		t.Fatalf("failed to associate organization; %s", err.Error())
+	}
+	apps, err := ident.ListOrganizationApplications(token, orgID, nil)
+	if err != nil || len(apps) != 1 || apps[0].ID != app.ID {
+		t.Fatalf("expected organization application; got %v, %v", apps, err)
+	}
+
+	orgToken, err := ident.CreateOrganizationToken(token, orgID, nil)
+	if err != nil {
+		t.Fatalf("failed to vend organization token; %s", err.Error())
+	}
+	claims, err := ident.NewJWTVerifier(nil, "", "").Verify(context.Background(), *orgToken.Token)
+	if err != nil {
+		t.Fatalf("failed to verify organization token; %s", err.Error())
+	}
+	if id := claims.OrganizationID(); id == nil || *id != org.ID {
+		t.Fatalf("expected organization token; got subject %s", claims.Subject)
+	}
+
+	usr, err := ident.CreateUser("", map[string]interface{}{
+		"email":    "invitee@example.com",
+		"password": "s3cr3t",
+	})
+	if err != nil {
+		t.Fatalf("failed to create user; %s", err.Error())
+	}
+	userID := usr.ID.String()
+	auth, err := ident.Authenticate("invitee@example.com", "s3cr3t")
+	if err != nil {
+		t.Fatalf("failed to authenticate; %s", err.Error())
+	}
+
+	err = ident.CreateInvitation(token, map[string]interface{}{
+		"email":           "invitee@example.com",
+		"application_id":  appID,
+		"organization_id": orgID,
+		"permissions":     ident.DefaultPermissions,
+	})
+	if err != nil {
+		t.Fatalf("failed to create invitation; %s", err.Error())
+	}
+	invites, err := ident.ListOrganizationInvitations(token, orgID, nil)
+	if err != nil || len(invites) != 1 {
+		t.Fatalf("expected organization invitation; got %v, %v", invites, err)
+	}
+	invite, err := ident.GetInvitation(token, invites[0].ID.String())
+	if err != nil {
+		t.Fatalf("failed to fetch invitation; %s", err.Error())
+	}
+	if *invite.Email != "invitee@example.com" || !invite.Permissions.Has(ident.PermissionAuthenticate) {
+		t.Fatalf("unexpected invitation: %+v", invite)
+	}
+
+	err = ident.AcceptInvitation(*auth.Token.AccessToken, invite.ID.String())
+	if err != nil {
+		t.Fatalf("failed to accept invitation; %s", err.Error())
+	}
+	users, err := ident.ListOrganizationUsers(token, orgID, nil)
+	if err != nil || len(users) != 1 || users[0].ID != usr.ID {
+		t.Fatalf("expected invitee to be an organization user; got %v, %v", users, err)
+	}
+
+	err = ident.UpdateApplicationUser(token, appID, userID, map[string]interface{}{
+		"permissions": ident.DefaultPermissions.Add(ident.PermissionGrantResourceAuthorization),
+	})
+	if err != nil {
+		t.Fatalf("failed to update application user; %s", err.Error())
+	}
+	users, err = ident.ListApplicationUsers(token, appID, nil)
+	if err != nil || len(users) != 1 || !users[0].Permissions.Has(ident.PermissionGrantResourceAuthorization) {
+		t.Fatalf("expected updated application user permissions; got %v, %v", users, err)
+	}
+
+	if err = ident.DeleteUser(token, userID); err != nil {
+		t.Fatalf("failed to delete user; %s", err.Error())
+	}
+	if err = ident.DeleteOrganization(token, orgID); err != nil {
+		t.Fatalf("failed to delete organization; %s", err.Error())
+	}
+	if _, err = ident.GetOrganizationDetails(token, orgID, nil); !errors.Is(err, api.ErrNotFound) {
+		t.Fatalf("expected deleted organization not to be found; got %v", err)
+	}
+}
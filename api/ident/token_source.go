@@ -0,0 +1,159 @@
+package ident
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// refreshTokenSourceExpiryLeeway is the amount of time prior to its expiration at which a
+// cached access token is considered expired
+const refreshTokenSourceExpiryLeeway = time.Minute
+
+// DefaultTokenTTL is the lifetime assumed for an access token whose expiration is reported
+// neither by ident nor by its exp claim, after which the token is refreshed
+const DefaultTokenTTL = time.Minute * 15
+
+// RefreshTokenSource is an api.TokenSource which exchanges a long-lived refresh token for
+// access tokens using the refresh_token grant, caching each access token until it nears
+// expiration; when ident rotates the refresh token, the rotated token is used thereafter
+type RefreshTokenSource struct {
+	service      *Service
+	refreshToken string
+	params       map[string]interface{}
+
+	mutex       sync.Mutex
+	accessToken *string
+	expiresAt   *time.Time
+}
+
+var _ api.TokenSource = (*RefreshTokenSource)(nil)
+var _ api.TokenInvalidator = (*RefreshTokenSource)(nil)
+
+// NewRefreshTokenSource initializes a *RefreshTokenSource for the given refresh token; the
+// optional params are included in each token request alongside the refresh_token grant type
+func NewRefreshTokenSource(refreshToken string, params map[string]interface{}) *RefreshTokenSource {
+	return &RefreshTokenSource{
+		refreshToken: refreshToken,
+		params:       params,
+	}
+}
+
+// NewRefreshTokenSourceWithService initializes a *RefreshTokenSource which authorizes access
+// tokens using the ident api configured on the given service in place of the environment
+func NewRefreshTokenSourceWithService(service *Service, refreshToken string, params map[string]interface{}) *RefreshTokenSource {
+	source := NewRefreshTokenSource(refreshToken, params)
+	source.service = service
+	return source
+}
+
+// Token returns the cached access token, or authorizes a new access token if none is cached
+// or the cached token expires within the leeway
+func (s *RefreshTokenSource) Token(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.accessToken != nil && s.expiresAt != nil && time.Now().Add(refreshTokenSourceExpiryLeeway).Before(*s.expiresAt) {
+		return *s.accessToken, nil
+	}
+
+	params := map[string]interface{}{}
+	for key, val := range s.params {
+		params[key] = val
+	}
+	params["grant_type"] = "refresh_token"
+
+	service := InitIdentService(common.StringOrNil(s.refreshToken))
+	if s.service != nil {
+		client := s.service.Client
+		client.Token = common.StringOrNil(s.refreshToken)
+		client.TokenSource = nil
+		service = &Service{client}
+	}
+
+	token, err := service.CreateToken(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to authorize access token using refresh token; %w", err)
+	}
+
+	if token.AccessToken == nil {
+		return "", errors.New("failed to authorize access token using refresh token; no access token returned")
+	}
+
+	s.accessToken = token.AccessToken
+	if token.RefreshToken != nil && *token.RefreshToken != s.refreshToken {
+		s.refreshToken = *token.RefreshToken
+		common.Log.Debugf("adopted rotated refresh token")
+	}
+	s.expiresAt = tokenExpiration(token)
+	if s.expiresAt == nil {
+		expiresAt := time.Now().Add(DefaultTokenTTL)
+		s.expiresAt = &expiresAt
+		common.Log.Debugf("access token expiration unknown; assuming ttl of %v", DefaultTokenTTL)
+	}
+	common.Log.Debugf("authorized access token using refresh token; expires at: %v", s.expiresAt)
+
+	return *s.accessToken, nil
+}
+
+// RefreshToken returns the current refresh token, which differs from the refresh token given
+// to the source once ident has rotated it
+func (s *RefreshTokenSource) RefreshToken() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.refreshToken
+}
+
+// ExpiresAt returns the expiration of the cached access token, which is DefaultTokenTTL after
+// it was authorized when its expiration is unknown
+func (s *RefreshTokenSource) ExpiresAt() *time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.expiresAt
+}
+
+// Invalidate discards the cached access token if it matches the given token, forcing a new
+// access token to be authorized by the next call to Token
+func (s *RefreshTokenSource) Invalidate(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.accessToken != nil && *s.accessToken == token {
+		s.accessToken = nil
+		s.expiresAt = nil
+	}
+}
+
+// tokenExpiration resolves the expiration of the access token using the expires_at or
+// expires_in fields of the response, falling back to the exp claim of the JWT itself
+func tokenExpiration(token *Token) *time.Time {
+	if token.ExpiresAt != nil {
+		return token.ExpiresAt
+	}
+
+	if token.ExpiresIn != nil {
+		issuedAt := time.Now()
+		if token.IssuedAt != nil {
+			issuedAt = *token.IssuedAt
+		}
+		expiresAt := issuedAt.Add(time.Duration(*token.ExpiresIn) * time.Second)
+		return &expiresAt
+	}
+
+	if token.AccessToken != nil {
+		var claims struct {
+			ExpiresAt *int64 `json:"exp"`
+		}
+		if unverifiedClaims(*token.AccessToken, &claims) && claims.ExpiresAt != nil {
+			expiresAt := time.Unix(*claims.ExpiresAt, 0)
+			return &expiresAt
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package ident
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+func TestRefreshTokenSourceAssumesDefaultTTL(t *testing.T) {
+	var grants int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&grants, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"access_token":"opaque"}`))
+	}))
+	defer srv.Close()
+
+	config := &api.Config{}
+	if err := config.Apply(api.WithURL(srv.URL + "/api/v1")); err != nil {
+		t.Fatal(err)
+	}
+	source := NewRefreshTokenSourceWithService(NewServiceWithConfig(config), "refresh", nil)
+
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil || token != "opaque" {
+			t.Fatalf("expected access token; got %s, %v", token, err)
+		}
+	}
+	if grants != 1 {
+		t.Errorf("expected the access token to be cached; got %d grants", grants)
+	}
+
+	expiresAt := source.ExpiresAt()
+	if expiresAt == nil || expiresAt.After(time.Now().Add(DefaultTokenTTL)) || expiresAt.Before(time.Now().Add(DefaultTokenTTL-time.Minute)) {
+		t.Fatalf("expected the access token to expire after the default ttl; got %v", expiresAt)
+	}
+
+	*source.expiresAt = time.Now()
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("failed to refresh access token; %s", err.Error())
+	}
+	if grants != 2 {
+		t.Errorf("expected the access token to be refreshed after the default ttl; got %d grants", grants)
+	}
+}
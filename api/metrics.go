@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency histogram buckets
+// recommended by the OpenTelemetry semantic conventions for HTTP client durations
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// Metrics records a measurement of each request sent by a client; it is implemented by
+// *LatencyHistogram, or by adapting the meter of a metrics library, such as the OpenTelemetry
+// adapter in the api/otel module
+type Metrics interface {
+	RecordRequest(ctx context.Context, m *RequestMeasurement)
+}
+
+// RequestMeasurement is the measurement of a request, including all of its attempts
+type RequestMeasurement struct {
+	Service  string
+	Method   string
+	Route    string
+	Status   int
+	Retries  int
+	Duration time.Duration
+	Err      error
+}
+
+// ErrorType returns the error.type of the request in accordance with the OpenTelemetry
+// semantic conventions, i.e. the status code of a 4xx or 5xx response, "timeout" or
+// "_OTHER", or an empty string if the request succeeded
+func (m *RequestMeasurement) ErrorType() string {
+	if m.Err == nil {
+		return ""
+	}
+
+	var apiErr *APIError
+	if errors.As(m.Err, &apiErr) {
+		return strconv.Itoa(apiErr.Status)
+	}
+
+	var netErr net.Error
+	if errors.Is(m.Err, context.DeadlineExceeded) || (errors.As(m.Err, &netErr) && netErr.Timeout()) {
+		return "timeout"
+	}
+	return "_OTHER"
+}
+
+// LatencyHistogram is an in-memory Metrics recorder which maintains a latency histogram and
+// error counts for each service, method and route
+type LatencyHistogram struct {
+	// Buckets are the upper bounds, in seconds, of the histogram buckets
+	Buckets []float64
+
+	series map[seriesKey]*HistogramSeries
+	mutex  sync.Mutex
+}
+
+// HistogramSeries is the latency histogram of the requests having a service, method and route;
+// Counts has one more element than Buckets, counting the requests exceeding the last bound
+type HistogramSeries struct {
+	Service string         `json:"service"`
+	Method  string         `json:"method"`
+	Route   string         `json:"route"`
+	Buckets []float64      `json:"buckets"`
+	Counts  []uint64       `json:"counts"`
+	Count   uint64         `json:"count"`
+	Sum     float64        `json:"sum"`
+	Errors  map[string]int `json:"errors,omitempty"`
+}
+
+type seriesKey struct {
+	service string
+	method  string
+	route   string
+}
+
+// NewLatencyHistogram returns a *LatencyHistogram using DefaultLatencyBuckets
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{
+		Buckets: DefaultLatencyBuckets,
+		series:  map[seriesKey]*HistogramSeries{},
+	}
+}
+
+// RecordRequest records the latency of the request, and its error type if it failed
+func (h *LatencyHistogram) RecordRequest(ctx context.Context, m *RequestMeasurement) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.series == nil {
+		h.series = map[seriesKey]*HistogramSeries{}
+	}
+
+	key := seriesKey{m.Service, m.Method, m.Route}
+	series, ok := h.series[key]
+	if !ok {
+		buckets := h.Buckets
+		if buckets == nil {
+			buckets = DefaultLatencyBuckets
+		}
+		series = &HistogramSeries{
+			Service: m.Service,
+			Method:  m.Method,
+			Route:   m.Route,
+			Buckets: buckets,
+			Counts:  make([]uint64, len(buckets)+1),
+			Errors:  map[string]int{},
+		}
+		h.series[key] = series
+	}
+
+	seconds := m.Duration.Seconds()
+	series.Counts[sort.SearchFloat64s(series.Buckets, seconds)]++
+	series.Count++
+	series.Sum += seconds
+
+	if errorType := m.ErrorType(); errorType != "" {
+		series.Errors[errorType]++
+	}
+}
+
+// Snapshot returns a copy of each series, ordered by service, route and method
+func (h *LatencyHistogram) Snapshot() []*HistogramSeries {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	snapshot := make([]*HistogramSeries, 0, len(h.series))
+	for _, series := range h.series {
+		s := *series
+		s.Counts = append([]uint64(nil), series.Counts...)
+		s.Errors = make(map[string]int, len(series.Errors))
+		for errorType, count := range series.Errors {
+			s.Errors[errorType] = count
+		}
+		snapshot = append(snapshot, &s)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		a, b := snapshot[i], snapshot[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.Route != b.Route {
+			return a.Route < b.Route
+		}
+		return a.Method < b.Method
+	})
+	return snapshot
+}
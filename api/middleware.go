@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedFields are the header and query parameter names which are always redacted
+// by the logging middleware
+var defaultRedactedFields = []string{
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"password",
+	"secret",
+	"token",
+	"x-api-key",
+}
+
+var (
+	middleware      []Middleware
+	middlewareMutex sync.RWMutex
+)
+
+// Middleware wraps the http.RoundTripper used to send API requests; middleware is invoked
+// once for each attempt, so a retried request passes through the chain multiple times.
+// Implementations must not modify the given request, but may send a clone of it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use registers middleware which is applied to the requests sent by every Client; middleware
+// registered with Use wraps middleware configured on an individual Client, and the first
+// middleware registered is the outermost
+func Use(mw ...Middleware) {
+	middlewareMutex.Lock()
+	defer middlewareMutex.Unlock()
+	middleware = append(middleware, mw...)
+}
+
+// ResetMiddleware removes all middleware registered with Use
+func ResetMiddleware() {
+	middlewareMutex.Lock()
+	defer middlewareMutex.Unlock()
+	middleware = nil
+}
+
+// chain wraps the given round tripper with the registered middleware followed by the
+// middleware configured on the client; the circuit breaker, rate limiter and cache of the
+// client, if any, are innermost so that the breaker and limiter observe only requests which
+// reach the network
+func (c *Client) chain(rt http.RoundTripper) http.RoundTripper {
+	if c.CircuitBreaker != nil {
+		rt = c.CircuitBreaker.middleware()(rt)
+	}
+	if c.RateLimiter != nil {
+		rt = c.RateLimiter.middleware()(rt)
+	}
+	if c.Cache != nil {
+		rt = cacheMiddleware(c.Cache)(rt)
+	}
+
+	middlewareMutex.RLock()
+	mw := make([]Middleware, 0, len(middleware)+len(c.Middleware))
+	mw = append(mw, middleware...)
+	middlewareMutex.RUnlock()
+	mw = append(mw, c.Middleware...)
+
+	for i := len(mw) - 1; i >= 0; i-- {
+		rt = mw[i](rt)
+	}
+	return rt
+}
+
+// HeaderMiddleware returns middleware which sets the given headers on each request,
+// replacing any values already present
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for name, val := range headers {
+				req.Header.Set(name, val)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LoggingMiddleware returns middleware which logs each request and its outcome at the debug
+// level; the values of sensitive headers and query parameters, and of any additionally
+// named fields, are redacted
+func LoggingMiddleware(redact ...string) Middleware {
+	redacted := map[string]bool{}
+	for _, field := range defaultRedactedFields {
+		redacted[field] = true
+	}
+	for _, field := range redact {
+		redacted[strings.ToLower(field)] = true
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			started := time.Now()
+			common.Log.Debugf("sending HTTP %s request: %s; headers: %s", req.Method, redactURL(req.URL, redacted), redactHeaders(req.Header, redacted))
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				common.Log.Debugf("HTTP %s request to %s failed after %v; %s", req.Method, redactURL(req.URL, redacted), time.Since(started), err.Error())
+				return resp, err
+			}
+
+			common.Log.Debugf("received %d response to HTTP %s request: %s in %v; headers: %s", resp.StatusCode, req.Method, redactURL(req.URL, redacted), time.Since(started), redactHeaders(resp.Header, redacted))
+			return resp, nil
+		})
+	}
+}
+
+func redactURL(u *url.URL, redacted map[string]bool) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	query := u.Query()
+	for key := range query {
+		if redacted[strings.ToLower(key)] {
+			query.Set(key, redactedValue)
+		}
+	}
+
+	redactedURL := *u
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+func redactHeaders(header http.Header, redacted map[string]bool) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		val := strings.Join(header[name], ", ")
+		if redacted[strings.ToLower(name)] {
+			val = redactedValue
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", name, val))
+	}
+	return strings.Join(fields, "; ")
+}
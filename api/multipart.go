@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// MultipartPart is a part of a streaming multipart/form-data request; a part having a Reader
+// or a Filename is sent as a file, otherwise Value is sent as an ordinary form field
+type MultipartPart struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Value       string
+	Reader      io.Reader
+}
+
+// FormField returns a *MultipartPart for an ordinary form field
+func FormField(name, value string) *MultipartPart {
+	return &MultipartPart{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// FormFile returns a *MultipartPart which streams the contents of the given reader as a file;
+// the content type defaults to application/octet-stream
+func FormFile(name, filename, contentType string, reader io.Reader) *MultipartPart {
+	return &MultipartPart{
+		Name:        name,
+		Filename:    filename,
+		ContentType: contentType,
+		Reader:      reader,
+	}
+}
+
+// PostMultipart streams an API POST request using multipart/form-data as the content-type;
+// each part is read as the request body is sent, so parts are never buffered in memory.
+// Since the parts cannot be read twice, the request is never retried, and it is bounded by
+// the given context and the client's Timeout, if set, rather than the default request timeout.
+// Readers are not closed.
+func (c *Client) PostMultipart(ctx context.Context, uri string, parts []*MultipartPart) (status int, response interface{}, err error) {
+	url := c.buildURL(uri)
+	resp, err := c.sendMultipartRequest(ctx, url, parts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return c.parseResponse(resp)
+}
+
+// PostMultipartInto streams an API POST request using multipart/form-data as the content-type
+// and decodes the response into target; see PostMultipart
+func (c *Client) PostMultipartInto(ctx context.Context, uri string, parts []*MultipartPart, target interface{}) (int, error) {
+	url := c.buildURL(uri)
+	resp, err := c.sendMultipartRequest(ctx, url, parts)
+	if err != nil {
+		return 0, err
+	}
+	return c.decodeResponse(resp, target)
+}
+
+func (c *Client) sendMultipartRequest(ctx context.Context, urlString string, parts []*MultipartPart) (resp *http.Response, err error) {
+	for _, part := range parts {
+		if part == nil || part.Name == "" {
+			return nil, errors.New("multipart/form-data parts must be named")
+		}
+	}
+
+	headers, err := c.requestHeaders(ctx, "POST", urlString)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL, err := url.Parse(urlString)
+	if err != nil {
+		common.Log.Warningf("failed to parse URL for HTTP POST request: %s; %s", urlString, err.Error())
+		return nil, err
+	}
+
+	ctx, telemetry := c.instrument(ctx, "POST", reqURL, headers)
+	defer func() {
+		telemetry.end(resp, err)
+	}()
+
+	body, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	headers["Content-Type"] = []string{writer.FormDataContentType()}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", urlString, body)
+	if err != nil {
+		common.Log.Warningf("failed to initialize HTTP POST request: %s; %s", urlString, err.Error())
+		return nil, err
+	}
+	req.Header = headers
+
+	go func() {
+		pw.CloseWithError(writeMultipartParts(writer, parts))
+	}()
+
+	client := c.streamingHTTPClient()
+
+	resp, err = client.Do(req)
+	if err != nil {
+		body.CloseWithError(err)
+		common.Log.Warningf("failed to stream multipart/form-data request: %s; %s", urlString, err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// writeMultipartParts writes each part followed by the closing boundary
+func writeMultipartParts(writer *multipart.Writer, parts []*MultipartPart) error {
+	for _, part := range parts {
+		if part.Reader == nil && part.Filename == "" {
+			err := writer.WriteField(part.Name, part.Value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		contentType := part.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(part.Name), escapeQuotes(part.Filename)))
+		header.Set("Content-Type", contentType)
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		if part.Reader != nil {
+			_, err = io.Copy(w, part.Reader)
+		} else {
+			_, err = io.WriteString(w, part.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write multipart/form-data part: %s; %s", part.Name, err.Error())
+		}
+	}
+
+	return writer.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
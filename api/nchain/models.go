@@ -35,6 +35,26 @@ type Account struct {
 	AccessedAt *time.Time `json:"accessed_at,omitempty"`
 }
 
+// Block represents a block collated on a network
+type Block struct {
+	NetworkID *uuid.UUID `json:"network_id,omitempty"`
+	Block     uint64     `json:"block"`
+	Hash      *string    `json:"hash,omitempty"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// Bridge represents a bridge between a network and another network
+type Bridge struct {
+	api.Model
+
+	ApplicationID  *uuid.UUID       `json:"application_id"`
+	NetworkID      uuid.UUID        `json:"network_id"`
+	OrganizationID *uuid.UUID       `json:"organization_id"`
+	Name           *string          `json:"name"`
+	Description    *string          `json:"description"`
+	Config         *json.RawMessage `json:"config,omitempty"`
+}
+
 // CompiledArtifact represents compiled sourcecode
 type CompiledArtifact struct {
 	Name        string          `json:"name"`
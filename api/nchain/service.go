@@ -1,9 +1,8 @@
 package nchain
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/api/ident"
@@ -19,47 +18,54 @@ type Service struct {
 	api.Client
 }
 
-// InitNChainService convenience method to initialize an `nchain.Service` instance
-func InitNChainService(token string) *Service {
-	host := defaultNChainHost
-	if os.Getenv("NCHAIN_API_HOST") != "" {
-		host = os.Getenv("NCHAIN_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the nchain api from the NCHAIN_API_HOST,
+// NCHAIN_API_PATH and NCHAIN_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("NCHAIN", defaultNChainHost, defaultNChainPath, defaultNChainScheme)
+}
 
-	path := defaultNChainPath
-	if os.Getenv("NCHAIN_API_PATH") != "" {
-		path = os.Getenv("NCHAIN_API_PATH")
+// NewService initializes an `nchain.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultNChainScheme
-	if os.Getenv("NCHAIN_API_SCHEME") != "" {
-		scheme = os.Getenv("NCHAIN_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  common.StringOrNil(token),
-		},
-	}
+// NewServiceWithConfig initializes an `nchain.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitNChainService convenience method to initialize an `nchain.Service` instance
+func InitNChainService(token string) *Service {
+	config := ConfigFromEnv()
+	config.Token = common.StringOrNil(token)
+	return NewServiceWithConfig(config)
 }
 
 // CreateAccount creates a new account
 func CreateAccount(token string, params map[string]interface{}) (*Account, error) {
-	uri := "accounts"
-	status, resp, err := InitNChainService(token).Post(uri, params)
+	return CreateAccountWithContext(context.Background(), token, params)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// CreateAccountWithContext creates a new account
+func CreateAccountWithContext(ctx context.Context, token string, params map[string]interface{}) (*Account, error) {
+	return InitNChainService(token).CreateAccount(ctx, params)
+}
 
+// CreateAccount creates a new account
+func (s *Service) CreateAccount(ctx context.Context, params map[string]interface{}) (*Account, error) {
+	uri := "accounts"
 	account := &Account{}
-	accountRaw, _ := json.Marshal(resp)
-	err = json.Unmarshal(accountRaw, &account)
+	status, err := s.PostInto(ctx, uri, params, account)
+
 	if err != nil {
-		return nil, fmt.Errorf("failed to create account. status: %v; %s", status, err.Error())
+		return nil, err
 	}
 
 	if status != 201 {
@@ -71,7 +77,18 @@ func CreateAccount(token string, params map[string]interface{}) (*Account, error
 
 // ListAccounts
 func ListAccounts(token string, params map[string]interface{}) ([]*Account, error) {
-	status, resp, err := InitNChainService(token).Get("accounts", params)
+	return ListAccountsWithContext(context.Background(), token, params)
+}
+
+// ListAccountsWithContext
+func ListAccountsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Account, error) {
+	return InitNChainService(token).ListAccounts(ctx, params)
+}
+
+// ListAccounts
+func (s *Service) ListAccounts(ctx context.Context, params map[string]interface{}) ([]*Account, error) {
+	accounts := make([]*Account, 0)
+	status, err := s.GetInto(ctx, "accounts", params, &accounts)
 	if err != nil {
 		return nil, err
 	}
@@ -80,20 +97,34 @@ func ListAccounts(token string, params map[string]interface{}) ([]*Account, erro
 		return nil, fmt.Errorf("failed to list accounts; status: %v", status)
 	}
 
-	accounts := make([]*Account, 0)
-	for _, item := range resp.([]interface{}) {
-		account := &Account{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &account)
-		accounts = append(accounts, account)
-	}
 	return accounts, nil
 }
 
+// ListAccountsPager returns an *api.Pager which walks all pages of the ListAccounts results
+func ListAccountsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListAccountsPager(params)
+}
+
+// ListAccountsPager returns an *api.Pager which walks all pages of the ListAccounts results
+func (s *Service) ListAccountsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("accounts", params)
+}
+
 // GetAccountDetails
 func GetAccountDetails(token, accountID string, params map[string]interface{}) (*Account, error) {
+	return GetAccountDetailsWithContext(context.Background(), token, accountID, params)
+}
+
+// GetAccountDetailsWithContext
+func GetAccountDetailsWithContext(ctx context.Context, token, accountID string, params map[string]interface{}) (*Account, error) {
+	return InitNChainService(token).GetAccountDetails(ctx, accountID, params)
+}
+
+// GetAccountDetails
+func (s *Service) GetAccountDetails(ctx context.Context, accountID string, params map[string]interface{}) (*Account, error) {
 	uri := fmt.Sprintf("accounts/%s", accountID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	account := &Account{}
+	status, err := s.GetInto(ctx, uri, params, account)
 	if err != nil {
 		return nil, err
 	}
@@ -102,41 +133,105 @@ func GetAccountDetails(token, accountID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to fetch account; status: %v", status)
 	}
 
-	account := &Account{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &account)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch account; status: %v; %s", status, err.Error())
-	}
-
 	return account, nil
 }
 
 // GetAccountBalance
 func GetAccountBalance(token, accountID, tokenID string, params map[string]interface{}) (int, interface{}, error) {
+	return GetAccountBalanceWithContext(context.Background(), token, accountID, tokenID, params)
+}
+
+// GetAccountBalanceWithContext
+func GetAccountBalanceWithContext(ctx context.Context, token, accountID, tokenID string, params map[string]interface{}) (int, interface{}, error) {
+	return InitNChainService(token).GetAccountBalance(ctx, accountID, tokenID, params)
+}
+
+// GetAccountBalance
+func (s *Service) GetAccountBalance(ctx context.Context, accountID, tokenID string, params map[string]interface{}) (int, interface{}, error) {
 	uri := fmt.Sprintf("accounts/%s/balances/%s", accountID, tokenID)
-	return InitNChainService(token).Get(uri, params)
+	return s.GetWithContext(ctx, uri, params)
 }
 
 // CreateBridge
 func CreateBridge(token string, params map[string]interface{}) (int, interface{}, error) {
-	return InitNChainService(token).Post("bridges", params)
+	return CreateBridgeWithContext(context.Background(), token, params)
+}
+
+// CreateBridgeWithContext
+func CreateBridgeWithContext(ctx context.Context, token string, params map[string]interface{}) (int, interface{}, error) {
+	return InitNChainService(token).CreateBridge(ctx, params)
+}
+
+// CreateBridge
+func (s *Service) CreateBridge(ctx context.Context, params map[string]interface{}) (int, interface{}, error) {
+	return s.PostWithContext(ctx, "bridges", params)
 }
 
 // ListBridges
-func ListBridges(token string, params map[string]interface{}) (int, interface{}, error) {
-	return InitNChainService(token).Get("bridges", params)
+func ListBridges(token string, params map[string]interface{}) ([]*Bridge, error) {
+	return ListBridgesWithContext(context.Background(), token, params)
+}
+
+// ListBridgesWithContext
+func ListBridgesWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Bridge, error) {
+	return InitNChainService(token).ListBridges(ctx, params)
+}
+
+// ListBridges
+func (s *Service) ListBridges(ctx context.Context, params map[string]interface{}) ([]*Bridge, error) {
+	bridges := make([]*Bridge, 0)
+	status, err := s.GetInto(ctx, "bridges", params, &bridges)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list bridges; status: %v", status)
+	}
+
+	return bridges, nil
+}
+
+// ListBridgesPager returns an *api.Pager which walks all pages of the ListBridges results
+func ListBridgesPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListBridgesPager(params)
+}
+
+// ListBridgesPager returns an *api.Pager which walks all pages of the ListBridges results
+func (s *Service) ListBridgesPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("bridges", params)
 }
 
 // GetBridgeDetails
 func GetBridgeDetails(token, bridgeID string, params map[string]interface{}) (int, interface{}, error) {
+	return GetBridgeDetailsWithContext(context.Background(), token, bridgeID, params)
+}
+
+// GetBridgeDetailsWithContext
+func GetBridgeDetailsWithContext(ctx context.Context, token, bridgeID string, params map[string]interface{}) (int, interface{}, error) {
+	return InitNChainService(token).GetBridgeDetails(ctx, bridgeID, params)
+}
+
+// GetBridgeDetails
+func (s *Service) GetBridgeDetails(ctx context.Context, bridgeID string, params map[string]interface{}) (int, interface{}, error) {
 	uri := fmt.Sprintf("bridges/%s", bridgeID)
-	return InitNChainService(token).Get(uri, params)
+	return s.GetWithContext(ctx, uri, params)
 }
 
 // CreateConnector
 func CreateConnector(token string, params map[string]interface{}) (*Connector, error) {
-	status, resp, err := InitNChainService(token).Post("connectors", params)
+	return CreateConnectorWithContext(context.Background(), token, params)
+}
+
+// CreateConnectorWithContext
+func CreateConnectorWithContext(ctx context.Context, token string, params map[string]interface{}) (*Connector, error) {
+	return InitNChainService(token).CreateConnector(ctx, params)
+}
+
+// CreateConnector
+func (s *Service) CreateConnector(ctx context.Context, params map[string]interface{}) (*Connector, error) {
+	connector := &Connector{}
+	status, err := s.PostInto(ctx, "connectors", params, connector)
 	if err != nil {
 		return nil, err
 	}
@@ -145,19 +240,23 @@ func CreateConnector(token string, params map[string]interface{}) (*Connector, e
 		return nil, fmt.Errorf("failed to create connector; status: %v", status)
 	}
 
-	connector := &Connector{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &connector)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create connector; status: %v; %s", status, err.Error())
-	}
-
 	return connector, nil
 }
 
 // ListConnectors
 func ListConnectors(token string, params map[string]interface{}) ([]*Connector, error) {
-	status, resp, err := InitNChainService(token).Get("connectors", params)
+	return ListConnectorsWithContext(context.Background(), token, params)
+}
+
+// ListConnectorsWithContext
+func ListConnectorsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Connector, error) {
+	return InitNChainService(token).ListConnectors(ctx, params)
+}
+
+// ListConnectors
+func (s *Service) ListConnectors(ctx context.Context, params map[string]interface{}) ([]*Connector, error) {
+	connectors := make([]*Connector, 0)
+	status, err := s.GetInto(ctx, "connectors", params, &connectors)
 	if err != nil {
 		return nil, err
 	}
@@ -166,20 +265,34 @@ func ListConnectors(token string, params map[string]interface{}) ([]*Connector,
 		return nil, fmt.Errorf("failed to list connectors; status: %v", status)
 	}
 
-	connectors := make([]*Connector, 0)
-	for _, item := range resp.([]interface{}) {
-		connector := &Connector{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &connector)
-		connectors = append(connectors, connector)
-	}
 	return connectors, nil
 }
 
+// ListConnectorsPager returns an *api.Pager which walks all pages of the ListConnectors results
+func ListConnectorsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListConnectorsPager(params)
+}
+
+// ListConnectorsPager returns an *api.Pager which walks all pages of the ListConnectors results
+func (s *Service) ListConnectorsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("connectors", params)
+}
+
 // GetConnectorDetails
 func GetConnectorDetails(token, connectorID string, params map[string]interface{}) (*Connector, error) {
+	return GetConnectorDetailsWithContext(context.Background(), token, connectorID, params)
+}
+
+// GetConnectorDetailsWithContext
+func GetConnectorDetailsWithContext(ctx context.Context, token, connectorID string, params map[string]interface{}) (*Connector, error) {
+	return InitNChainService(token).GetConnectorDetails(ctx, connectorID, params)
+}
+
+// GetConnectorDetails
+func (s *Service) GetConnectorDetails(ctx context.Context, connectorID string, params map[string]interface{}) (*Connector, error) {
 	uri := fmt.Sprintf("connectors/%s", connectorID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	connector := &Connector{}
+	status, err := s.GetInto(ctx, uri, params, connector)
 	if err != nil {
 		return nil, err
 	}
@@ -188,20 +301,23 @@ func GetConnectorDetails(token, connectorID string, params map[string]interface{
 		return nil, fmt.Errorf("failed to fetch connector; status: %v", status)
 	}
 
-	connector := &Connector{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &connector)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch connector; status: %v; %s", status, err.Error())
-	}
-
 	return connector, nil
 }
 
 // DeleteConnector
 func DeleteConnector(token, connectorID string) error {
+	return DeleteConnectorWithContext(context.Background(), token, connectorID)
+}
+
+// DeleteConnectorWithContext
+func DeleteConnectorWithContext(ctx context.Context, token, connectorID string) error {
+	return InitNChainService(token).DeleteConnector(ctx, connectorID)
+}
+
+// DeleteConnector
+func (s *Service) DeleteConnector(ctx context.Context, connectorID string) error {
 	uri := fmt.Sprintf("connectors/%s", connectorID)
-	status, _, err := InitNChainService(token).Delete(uri)
+	status, _, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -215,16 +331,20 @@ func DeleteConnector(token, connectorID string) error {
 
 // CreateContract
 func CreateContract(token string, params map[string]interface{}) (*Contract, error) {
-	status, resp, err := InitNChainService(token).Post("contracts", params)
-	if err != nil {
-		return nil, err
-	}
+	return CreateContractWithContext(context.Background(), token, params)
+}
 
+// CreateContractWithContext
+func CreateContractWithContext(ctx context.Context, token string, params map[string]interface{}) (*Contract, error) {
+	return InitNChainService(token).CreateContract(ctx, params)
+}
+
+// CreateContract
+func (s *Service) CreateContract(ctx context.Context, params map[string]interface{}) (*Contract, error) {
 	contract := &Contract{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &contract)
+	status, err := s.PostInto(ctx, "contracts", params, contract)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create contract; status: %v; %s", status, err.Error())
+		return nil, err
 	}
 
 	if status != 201 {
@@ -238,8 +358,23 @@ func CreateContract(token string, params map[string]interface{}) (*Contract, err
 // for arbitrary transaction execution
 // this can be used for org registries, erc20 etc.
 func CreatePublicContract(token string, params map[string]interface{}) (*Contract, error) {
+	return CreatePublicContractWithContext(context.Background(), token, params)
+}
+
+// CreatePublicContractWithContext loads an already deployed contract into nchain
+// for arbitrary transaction execution
+// this can be used for org registries, erc20 etc.
+func CreatePublicContractWithContext(ctx context.Context, token string, params map[string]interface{}) (*Contract, error) {
+	return InitNChainService(token).CreatePublicContract(ctx, params)
+}
+
+// CreatePublicContract loads an already deployed contract into nchain
+// for arbitrary transaction execution
+// this can be used for org registries, erc20 etc.
+func (s *Service) CreatePublicContract(ctx context.Context, params map[string]interface{}) (*Contract, error) {
 	uri := "public/contracts"
-	status, resp, err := InitNChainService(token).Post(uri, params)
+	contract := &Contract{}
+	status, err := s.PostInto(ctx, uri, params, contract)
 
 	if err != nil {
 		return nil, err
@@ -249,20 +384,24 @@ func CreatePublicContract(token string, params map[string]interface{}) (*Contrac
 		return nil, fmt.Errorf("failed to create public contract. status: %v", status)
 	}
 
-	contract := &Contract{}
-	contractRaw, _ := json.Marshal(resp)
-	err = json.Unmarshal(contractRaw, &contract)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create public contract. status: %v; %s", status, err.Error())
-	}
-
 	return contract, nil
 }
 
 // ExecuteContract
 func ExecuteContract(token, contractID string, params map[string]interface{}) (*ContractExecutionResponse, error) {
+	return ExecuteContractWithContext(context.Background(), token, contractID, params)
+}
+
+// ExecuteContractWithContext
+func ExecuteContractWithContext(ctx context.Context, token, contractID string, params map[string]interface{}) (*ContractExecutionResponse, error) {
+	return InitNChainService(token).ExecuteContract(ctx, contractID, params)
+}
+
+// ExecuteContract
+func (s *Service) ExecuteContract(ctx context.Context, contractID string, params map[string]interface{}) (*ContractExecutionResponse, error) {
 	uri := fmt.Sprintf("contracts/%s/execute", contractID)
-	status, resp, err := InitNChainService(token).Post(uri, params)
+	execResponse := &ContractExecutionResponse{}
+	status, err := s.PostInto(ctx, uri, params, execResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -271,19 +410,23 @@ func ExecuteContract(token, contractID string, params map[string]interface{}) (*
 		return nil, fmt.Errorf("failed to execute contract; status %v", status)
 	}
 
-	execResponse := &ContractExecutionResponse{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &execResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute contract; status: %v; %s", status, err.Error())
-	}
-
 	return execResponse, nil
 }
 
 // ListContracts
 func ListContracts(token string, params map[string]interface{}) ([]*Contract, error) {
-	status, resp, err := InitNChainService(token).Get("contracts", params)
+	return ListContractsWithContext(context.Background(), token, params)
+}
+
+// ListContractsWithContext
+func ListContractsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Contract, error) {
+	return InitNChainService(token).ListContracts(ctx, params)
+}
+
+// ListContracts
+func (s *Service) ListContracts(ctx context.Context, params map[string]interface{}) ([]*Contract, error) {
+	contracts := make([]*Contract, 0)
+	status, err := s.GetInto(ctx, "contracts", params, &contracts)
 	if err != nil {
 		return nil, err
 	}
@@ -292,20 +435,34 @@ func ListContracts(token string, params map[string]interface{}) ([]*Contract, er
 		return nil, fmt.Errorf("failed to list contracts; status: %v", status)
 	}
 
-	contracts := make([]*Contract, 0)
-	for _, item := range resp.([]interface{}) {
-		contract := &Contract{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &contract)
-		contracts = append(contracts, contract)
-	}
 	return contracts, nil
 }
 
+// ListContractsPager returns an *api.Pager which walks all pages of the ListContracts results
+func ListContractsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListContractsPager(params)
+}
+
+// ListContractsPager returns an *api.Pager which walks all pages of the ListContracts results
+func (s *Service) ListContractsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("contracts", params)
+}
+
 // GetContractDetails
 func GetContractDetails(token, contractID string, params map[string]interface{}) (*Contract, error) {
+	return GetContractDetailsWithContext(context.Background(), token, contractID, params)
+}
+
+// GetContractDetailsWithContext
+func GetContractDetailsWithContext(ctx context.Context, token, contractID string, params map[string]interface{}) (*Contract, error) {
+	return InitNChainService(token).GetContractDetails(ctx, contractID, params)
+}
+
+// GetContractDetails
+func (s *Service) GetContractDetails(ctx context.Context, contractID string, params map[string]interface{}) (*Contract, error) {
 	uri := fmt.Sprintf("contracts/%s", contractID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	contract := &Contract{}
+	status, err := s.GetInto(ctx, uri, params, contract)
 	if err != nil {
 		return nil, err
 	}
@@ -314,20 +471,24 @@ func GetContractDetails(token, contractID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to fetch contract; status %v", status)
 	}
 
-	contract := &Contract{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &contract)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch contract; status: %v; %s", status, err.Error())
-	}
-
 	return contract, nil
 }
 
 // VendContractSubscriptionToken
 func VendContractSubscriptionToken(token, contractID string, params map[string]interface{}) (*ident.Token, error) {
+	return VendContractSubscriptionTokenWithContext(context.Background(), token, contractID, params)
+}
+
+// VendContractSubscriptionTokenWithContext
+func VendContractSubscriptionTokenWithContext(ctx context.Context, token, contractID string, params map[string]interface{}) (*ident.Token, error) {
+	return InitNChainService(token).VendContractSubscriptionToken(ctx, contractID, params)
+}
+
+// VendContractSubscriptionToken
+func (s *Service) VendContractSubscriptionToken(ctx context.Context, contractID string, params map[string]interface{}) (*ident.Token, error) {
 	uri := fmt.Sprintf("contracts/%s/subscriptions", contractID)
-	status, resp, err := InitNChainService(token).Post(uri, params)
+	tkn := &ident.Token{}
+	status, err := s.PostInto(ctx, uri, params, tkn)
 	if err != nil {
 		return nil, err
 	}
@@ -336,19 +497,23 @@ func VendContractSubscriptionToken(token, contractID string, params map[string]i
 		return nil, fmt.Errorf("failed to vend contract subscription token; status %v", status)
 	}
 
-	tkn := &ident.Token{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &tkn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch contract subscription token; status: %v; %s", status, err.Error())
-	}
-
 	return tkn, nil
 }
 
 // CreateNetwork creates a new network
 func CreateNetwork(token string, params map[string]interface{}) (*Network, error) {
-	status, resp, err := InitNChainService(token).Post("networks", params)
+	return CreateNetworkWithContext(context.Background(), token, params)
+}
+
+// CreateNetworkWithContext creates a new network
+func CreateNetworkWithContext(ctx context.Context, token string, params map[string]interface{}) (*Network, error) {
+	return InitNChainService(token).CreateNetwork(ctx, params)
+}
+
+// CreateNetwork creates a new network
+func (s *Service) CreateNetwork(ctx context.Context, params map[string]interface{}) (*Network, error) {
+	network := &Network{}
+	status, err := s.PostInto(ctx, "networks", params, network)
 	if err != nil {
 		return nil, err
 	}
@@ -357,20 +522,23 @@ func CreateNetwork(token string, params map[string]interface{}) (*Network, error
 		return nil, fmt.Errorf("failed to create network; status: %v", status)
 	}
 
-	network := &Network{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &network)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create network; status: %v; %s", status, err.Error())
-	}
-
 	return network, nil
 }
 
 // UpdateNetwork updates an existing network
 func UpdateNetwork(token, networkID string, params map[string]interface{}) error {
+	return UpdateNetworkWithContext(context.Background(), token, networkID, params)
+}
+
+// UpdateNetworkWithContext updates an existing network
+func UpdateNetworkWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) error {
+	return InitNChainService(token).UpdateNetwork(ctx, networkID, params)
+}
+
+// UpdateNetwork updates an existing network
+func (s *Service) UpdateNetwork(ctx context.Context, networkID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("networks/%s", networkID)
-	status, _, err := InitNChainService(token).Put(uri, params)
+	status, _, err := s.PutWithContext(ctx, uri, params)
 	if err != nil {
 		return err
 	}
@@ -385,8 +553,19 @@ func UpdateNetwork(token, networkID string, params map[string]interface{}) error
 
 // ListNetworks
 func ListNetworks(token string, params map[string]interface{}) ([]*Network, error) {
+	return ListNetworksWithContext(context.Background(), token, params)
+}
+
+// ListNetworksWithContext
+func ListNetworksWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Network, error) {
+	return InitNChainService(token).ListNetworks(ctx, params)
+}
+
+// ListNetworks
+func (s *Service) ListNetworks(ctx context.Context, params map[string]interface{}) ([]*Network, error) {
 	uri := "networks"
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	networks := make([]*Network, 0)
+	status, err := s.GetInto(ctx, uri, params, &networks)
 	if err != nil {
 		return nil, err
 	}
@@ -395,20 +574,34 @@ func ListNetworks(token string, params map[string]interface{}) ([]*Network, erro
 		return nil, fmt.Errorf("failed to list networks. status: %v", status)
 	}
 
-	networks := make([]*Network, 0)
-	for _, item := range resp.([]interface{}) {
-		netwrk := &Network{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &netwrk)
-		networks = append(networks, netwrk)
-	}
 	return networks, nil
 }
 
+// ListNetworksPager returns an *api.Pager which walks all pages of the ListNetworks results
+func ListNetworksPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworksPager(params)
+}
+
+// ListNetworksPager returns an *api.Pager which walks all pages of the ListNetworks results
+func (s *Service) ListNetworksPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("networks", params)
+}
+
 // GetNetworkDetails returns the details for the specified network id
 func GetNetworkDetails(token, networkID string, params map[string]interface{}) (*Network, error) {
+	return GetNetworkDetailsWithContext(context.Background(), token, networkID, params)
+}
+
+// GetNetworkDetailsWithContext returns the details for the specified network id
+func GetNetworkDetailsWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) (*Network, error) {
+	return InitNChainService(token).GetNetworkDetails(ctx, networkID, params)
+}
+
+// GetNetworkDetails returns the details for the specified network id
+func (s *Service) GetNetworkDetails(ctx context.Context, networkID string, params map[string]interface{}) (*Network, error) {
 	uri := fmt.Sprintf("networks/%s", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	network := &Network{}
+	status, err := s.GetInto(ctx, uri, params, network)
 	if err != nil {
 		return nil, err
 	}
@@ -417,20 +610,24 @@ func GetNetworkDetails(token, networkID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to fetch network. status %v", status)
 	}
 
-	network := &Network{}
-	networkRaw, _ := json.Marshal(resp)
-	err = json.Unmarshal(networkRaw, &network)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch network details. status: %v; %s", status, err.Error())
-	}
-
 	return network, nil
 }
 
 // ListNetworkAccounts
 func ListNetworkAccounts(token, networkID string, params map[string]interface{}) ([]*Account, error) {
+	return ListNetworkAccountsWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkAccountsWithContext
+func ListNetworkAccountsWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Account, error) {
+	return InitNChainService(token).ListNetworkAccounts(ctx, networkID, params)
+}
+
+// ListNetworkAccounts
+func (s *Service) ListNetworkAccounts(ctx context.Context, networkID string, params map[string]interface{}) ([]*Account, error) {
 	uri := fmt.Sprintf("networks/%s/accounts", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	accounts := make([]*Account, 0)
+	status, err := s.GetInto(ctx, uri, params, &accounts)
 	if err != nil {
 		return nil, err
 	}
@@ -439,32 +636,109 @@ func ListNetworkAccounts(token, networkID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to list accounts; status: %v", status)
 	}
 
-	accounts := make([]*Account, 0)
-	for _, item := range resp.([]interface{}) {
-		account := &Account{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &account)
-		accounts = append(accounts, account)
-	}
 	return accounts, nil
 }
 
+// ListNetworkAccountsPager returns an *api.Pager which walks all pages of the ListNetworkAccounts results
+func ListNetworkAccountsPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkAccountsPager(networkID, params)
+}
+
+// ListNetworkAccountsPager returns an *api.Pager which walks all pages of the ListNetworkAccounts results
+func (s *Service) ListNetworkAccountsPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/accounts", networkID)
+	return s.Pager(uri, params)
+}
+
+// ListNetworkBlocks
+func ListNetworkBlocks(token, networkID string, params map[string]interface{}) ([]*Block, error) {
+	return ListNetworkBlocksWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkBlocksWithContext
+func ListNetworkBlocksWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Block, error) {
+	return InitNChainService(token).ListNetworkBlocks(ctx, networkID, params)
+}
+
 // ListNetworkBlocks
-func ListNetworkBlocks(token, networkID string, params map[string]interface{}) (int, interface{}, error) {
+func (s *Service) ListNetworkBlocks(ctx context.Context, networkID string, params map[string]interface{}) ([]*Block, error) {
 	uri := fmt.Sprintf("networks/%s/blocks", networkID)
-	return InitNChainService(token).Get(uri, params)
+	blocks := make([]*Block, 0)
+	status, err := s.GetInto(ctx, uri, params, &blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list blocks; status: %v", status)
+	}
+
+	return blocks, nil
+}
+
+// ListNetworkBlocksPager returns an *api.Pager which walks all pages of the ListNetworkBlocks results
+func ListNetworkBlocksPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkBlocksPager(networkID, params)
+}
+
+// ListNetworkBlocksPager returns an *api.Pager which walks all pages of the ListNetworkBlocks results
+func (s *Service) ListNetworkBlocksPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/blocks", networkID)
+	return s.Pager(uri, params)
+}
+
+// ListNetworkBridges
+func ListNetworkBridges(token, networkID string, params map[string]interface{}) ([]*Bridge, error) {
+	return ListNetworkBridgesWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkBridgesWithContext
+func ListNetworkBridgesWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Bridge, error) {
+	return InitNChainService(token).ListNetworkBridges(ctx, networkID, params)
 }
 
 // ListNetworkBridges
-func ListNetworkBridges(token, networkID string, params map[string]interface{}) (int, interface{}, error) {
+func (s *Service) ListNetworkBridges(ctx context.Context, networkID string, params map[string]interface{}) ([]*Bridge, error) {
 	uri := fmt.Sprintf("networks/%s/bridges", networkID)
-	return InitNChainService(token).Get(uri, params)
+	bridges := make([]*Bridge, 0)
+	status, err := s.GetInto(ctx, uri, params, &bridges)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list bridges; status: %v", status)
+	}
+
+	return bridges, nil
+}
+
+// ListNetworkBridgesPager returns an *api.Pager which walks all pages of the ListNetworkBridges results
+func ListNetworkBridgesPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkBridgesPager(networkID, params)
+}
+
+// ListNetworkBridgesPager returns an *api.Pager which walks all pages of the ListNetworkBridges results
+func (s *Service) ListNetworkBridgesPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/bridges", networkID)
+	return s.Pager(uri, params)
 }
 
 // ListNetworkConnectors
 func ListNetworkConnectors(token, networkID string, params map[string]interface{}) ([]*Connector, error) {
+	return ListNetworkConnectorsWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkConnectorsWithContext
+func ListNetworkConnectorsWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Connector, error) {
+	return InitNChainService(token).ListNetworkConnectors(ctx, networkID, params)
+}
+
+// ListNetworkConnectors
+func (s *Service) ListNetworkConnectors(ctx context.Context, networkID string, params map[string]interface{}) ([]*Connector, error) {
 	uri := fmt.Sprintf("networks/%s/connectors", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	connectors := make([]*Connector, 0)
+	status, err := s.GetInto(ctx, uri, params, &connectors)
 	if err != nil {
 		return nil, err
 	}
@@ -473,20 +747,35 @@ func ListNetworkConnectors(token, networkID string, params map[string]interface{
 		return nil, fmt.Errorf("failed to list connectors; status: %v", status)
 	}
 
-	connectors := make([]*Connector, 0)
-	for _, item := range resp.([]interface{}) {
-		connector := &Connector{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &connector)
-		connectors = append(connectors, connector)
-	}
 	return connectors, nil
 }
 
+// ListNetworkConnectorsPager returns an *api.Pager which walks all pages of the ListNetworkConnectors results
+func ListNetworkConnectorsPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkConnectorsPager(networkID, params)
+}
+
+// ListNetworkConnectorsPager returns an *api.Pager which walks all pages of the ListNetworkConnectors results
+func (s *Service) ListNetworkConnectorsPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/connectors", networkID)
+	return s.Pager(uri, params)
+}
+
 // ListNetworkContracts
 func ListNetworkContracts(token, networkID string, params map[string]interface{}) ([]*Contract, error) {
+	return ListNetworkContractsWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkContractsWithContext
+func ListNetworkContractsWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Contract, error) {
+	return InitNChainService(token).ListNetworkContracts(ctx, networkID, params)
+}
+
+// ListNetworkContracts
+func (s *Service) ListNetworkContracts(ctx context.Context, networkID string, params map[string]interface{}) ([]*Contract, error) {
 	uri := fmt.Sprintf("networks/%s/contracts", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	contracts := make([]*Contract, 0)
+	status, err := s.GetInto(ctx, uri, params, &contracts)
 	if err != nil {
 		return nil, err
 	}
@@ -495,20 +784,35 @@ func ListNetworkContracts(token, networkID string, params map[string]interface{}
 		return nil, fmt.Errorf("failed to list contracts; status: %v", status)
 	}
 
-	contracts := make([]*Contract, 0)
-	for _, item := range resp.([]interface{}) {
-		contract := &Contract{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &contract)
-		contracts = append(contracts, contract)
-	}
 	return contracts, nil
 }
 
+// ListNetworkContractsPager returns an *api.Pager which walks all pages of the ListNetworkContracts results
+func ListNetworkContractsPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkContractsPager(networkID, params)
+}
+
+// ListNetworkContractsPager returns an *api.Pager which walks all pages of the ListNetworkContracts results
+func (s *Service) ListNetworkContractsPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/contracts", networkID)
+	return s.Pager(uri, params)
+}
+
 // GetNetworkContractDetails
 func GetNetworkContractDetails(token, networkID, contractID string, params map[string]interface{}) (*Contract, error) {
+	return GetNetworkContractDetailsWithContext(context.Background(), token, networkID, contractID, params)
+}
+
+// GetNetworkContractDetailsWithContext
+func GetNetworkContractDetailsWithContext(ctx context.Context, token, networkID, contractID string, params map[string]interface{}) (*Contract, error) {
+	return InitNChainService(token).GetNetworkContractDetails(ctx, networkID, contractID, params)
+}
+
+// GetNetworkContractDetails
+func (s *Service) GetNetworkContractDetails(ctx context.Context, networkID, contractID string, params map[string]interface{}) (*Contract, error) {
 	uri := fmt.Sprintf("networks/%s/contracts/%s", networkID, contractID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	contract := &Contract{}
+	status, err := s.GetInto(ctx, uri, params, contract)
 	if err != nil {
 		return nil, err
 	}
@@ -517,20 +821,24 @@ func GetNetworkContractDetails(token, networkID, contractID string, params map[s
 		return nil, fmt.Errorf("failed to fetch contract; status %v", status)
 	}
 
-	contract := &Contract{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &contract)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch contract; status: %v; %s", status, err.Error())
-	}
-
 	return contract, nil
 }
 
 // ListNetworkOracles
 func ListNetworkOracles(token, networkID string, params map[string]interface{}) ([]*Oracle, error) {
+	return ListNetworkOraclesWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkOraclesWithContext
+func ListNetworkOraclesWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Oracle, error) {
+	return InitNChainService(token).ListNetworkOracles(ctx, networkID, params)
+}
+
+// ListNetworkOracles
+func (s *Service) ListNetworkOracles(ctx context.Context, networkID string, params map[string]interface{}) ([]*Oracle, error) {
 	uri := fmt.Sprintf("networks/%s/oracles", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	oracles := make([]*Oracle, 0)
+	status, err := s.GetInto(ctx, uri, params, &oracles)
 	if err != nil {
 		return nil, err
 	}
@@ -539,20 +847,35 @@ func ListNetworkOracles(token, networkID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to list oracles; status: %v", status)
 	}
 
-	oracles := make([]*Oracle, 0)
-	for _, item := range resp.([]interface{}) {
-		oracle := &Oracle{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &oracle)
-		oracles = append(oracles, oracle)
-	}
 	return oracles, nil
 }
 
+// ListNetworkOraclesPager returns an *api.Pager which walks all pages of the ListNetworkOracles results
+func ListNetworkOraclesPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkOraclesPager(networkID, params)
+}
+
+// ListNetworkOraclesPager returns an *api.Pager which walks all pages of the ListNetworkOracles results
+func (s *Service) ListNetworkOraclesPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/oracles", networkID)
+	return s.Pager(uri, params)
+}
+
 // ListNetworkTokens
 func ListNetworkTokens(token, networkID string, params map[string]interface{}) ([]*Token, error) {
+	return ListNetworkTokensWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkTokensWithContext
+func ListNetworkTokensWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Token, error) {
+	return InitNChainService(token).ListNetworkTokens(ctx, networkID, params)
+}
+
+// ListNetworkTokens
+func (s *Service) ListNetworkTokens(ctx context.Context, networkID string, params map[string]interface{}) ([]*Token, error) {
 	uri := fmt.Sprintf("networks/%s/tokens", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	tknContracts := make([]*Token, 0)
+	status, err := s.GetInto(ctx, uri, params, &tknContracts)
 	if err != nil {
 		return nil, err
 	}
@@ -561,20 +884,35 @@ func ListNetworkTokens(token, networkID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list token contracts; status: %v", status)
 	}
 
-	tknContracts := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tknContract := &Token{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tknContract)
-		tknContracts = append(tknContracts, tknContract)
-	}
 	return tknContracts, nil
 }
 
+// ListNetworkTokensPager returns an *api.Pager which walks all pages of the ListNetworkTokens results
+func ListNetworkTokensPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkTokensPager(networkID, params)
+}
+
+// ListNetworkTokensPager returns an *api.Pager which walks all pages of the ListNetworkTokens results
+func (s *Service) ListNetworkTokensPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/tokens", networkID)
+	return s.Pager(uri, params)
+}
+
 // ListNetworkTransactions
 func ListNetworkTransactions(token, networkID string, params map[string]interface{}) ([]*Transaction, error) {
+	return ListNetworkTransactionsWithContext(context.Background(), token, networkID, params)
+}
+
+// ListNetworkTransactionsWithContext
+func ListNetworkTransactionsWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) ([]*Transaction, error) {
+	return InitNChainService(token).ListNetworkTransactions(ctx, networkID, params)
+}
+
+// ListNetworkTransactions
+func (s *Service) ListNetworkTransactions(ctx context.Context, networkID string, params map[string]interface{}) ([]*Transaction, error) {
 	uri := fmt.Sprintf("networks/%s/transactions", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	txs := make([]*Transaction, 0)
+	status, err := s.GetInto(ctx, uri, params, &txs)
 	if err != nil {
 		return nil, err
 	}
@@ -583,20 +921,35 @@ func ListNetworkTransactions(token, networkID string, params map[string]interfac
 		return nil, fmt.Errorf("failed to list transactions; status: %v", status)
 	}
 
-	txs := make([]*Transaction, 0)
-	for _, item := range resp.([]interface{}) {
-		tx := &Transaction{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tx)
-		txs = append(txs, tx)
-	}
 	return txs, nil
 }
 
+// ListNetworkTransactionsPager returns an *api.Pager which walks all pages of the ListNetworkTransactions results
+func ListNetworkTransactionsPager(token, networkID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListNetworkTransactionsPager(networkID, params)
+}
+
+// ListNetworkTransactionsPager returns an *api.Pager which walks all pages of the ListNetworkTransactions results
+func (s *Service) ListNetworkTransactionsPager(networkID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("networks/%s/transactions", networkID)
+	return s.Pager(uri, params)
+}
+
 // GetNetworkTransactionDetails
 func GetNetworkTransactionDetails(token, networkID, txID string, params map[string]interface{}) (*Transaction, error) {
+	return GetNetworkTransactionDetailsWithContext(context.Background(), token, networkID, txID, params)
+}
+
+// GetNetworkTransactionDetailsWithContext
+func GetNetworkTransactionDetailsWithContext(ctx context.Context, token, networkID, txID string, params map[string]interface{}) (*Transaction, error) {
+	return InitNChainService(token).GetNetworkTransactionDetails(ctx, networkID, txID, params)
+}
+
+// GetNetworkTransactionDetails
+func (s *Service) GetNetworkTransactionDetails(ctx context.Context, networkID, txID string, params map[string]interface{}) (*Transaction, error) {
 	uri := fmt.Sprintf("networks/%s/transactions/%s", networkID, txID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	tx := &Transaction{}
+	status, err := s.GetInto(ctx, uri, params, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -605,20 +958,24 @@ func GetNetworkTransactionDetails(token, networkID, txID string, params map[stri
 		return nil, fmt.Errorf("failed to fetch tx; status %v", status)
 	}
 
-	tx := &Transaction{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &tx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tx; status: %v; %s", status, err.Error())
-	}
-
 	return tx, nil
 }
 
 // GetNetworkStatusMeta returns the status details for the specified network
 func GetNetworkStatusMeta(token, networkID string, params map[string]interface{}) (*NetworkStatus, error) {
+	return GetNetworkStatusMetaWithContext(context.Background(), token, networkID, params)
+}
+
+// GetNetworkStatusMetaWithContext returns the status details for the specified network
+func GetNetworkStatusMetaWithContext(ctx context.Context, token, networkID string, params map[string]interface{}) (*NetworkStatus, error) {
+	return InitNChainService(token).GetNetworkStatusMeta(ctx, networkID, params)
+}
+
+// GetNetworkStatusMeta returns the status details for the specified network
+func (s *Service) GetNetworkStatusMeta(ctx context.Context, networkID string, params map[string]interface{}) (*NetworkStatus, error) {
 	uri := fmt.Sprintf("networks/%s/status", networkID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	networkStatus := &NetworkStatus{}
+	status, err := s.GetInto(ctx, uri, params, networkStatus)
 	if err != nil {
 		return nil, err
 	}
@@ -627,19 +984,23 @@ func GetNetworkStatusMeta(token, networkID string, params map[string]interface{}
 		return nil, fmt.Errorf("failed to fetch network. status %v", status)
 	}
 
-	networkStatus := &NetworkStatus{}
-	networkStatusRaw, _ := json.Marshal(resp)
-	err = json.Unmarshal(networkStatusRaw, &networkStatus)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch network status. status: %v; %s", status, err.Error())
-	}
-
 	return networkStatus, nil
 }
 
 // CreateOracle
 func CreateOracle(token string, params map[string]interface{}) (*Oracle, error) {
-	status, resp, err := InitNChainService(token).Post("oracles", params)
+	return CreateOracleWithContext(context.Background(), token, params)
+}
+
+// CreateOracleWithContext
+func CreateOracleWithContext(ctx context.Context, token string, params map[string]interface{}) (*Oracle, error) {
+	return InitNChainService(token).CreateOracle(ctx, params)
+}
+
+// CreateOracle
+func (s *Service) CreateOracle(ctx context.Context, params map[string]interface{}) (*Oracle, error) {
+	oracle := &Oracle{}
+	status, err := s.PostInto(ctx, "oracles", params, oracle)
 	if err != nil {
 		return nil, err
 	}
@@ -648,19 +1009,23 @@ func CreateOracle(token string, params map[string]interface{}) (*Oracle, error)
 		return nil, fmt.Errorf("failed to create oracle; status: %v", status)
 	}
 
-	oracle := &Oracle{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &oracle)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create oracle; status: %v; %s", status, err.Error())
-	}
-
 	return oracle, nil
 }
 
 // ListOracles
 func ListOracles(token string, params map[string]interface{}) ([]*Oracle, error) {
-	status, resp, err := InitNChainService(token).Get("oracles", params)
+	return ListOraclesWithContext(context.Background(), token, params)
+}
+
+// ListOraclesWithContext
+func ListOraclesWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Oracle, error) {
+	return InitNChainService(token).ListOracles(ctx, params)
+}
+
+// ListOracles
+func (s *Service) ListOracles(ctx context.Context, params map[string]interface{}) ([]*Oracle, error) {
+	oracles := make([]*Oracle, 0)
+	status, err := s.GetInto(ctx, "oracles", params, &oracles)
 	if err != nil {
 		return nil, err
 	}
@@ -669,20 +1034,34 @@ func ListOracles(token string, params map[string]interface{}) ([]*Oracle, error)
 		return nil, fmt.Errorf("failed to list oracles; status: %v", status)
 	}
 
-	oracles := make([]*Oracle, 0)
-	for _, item := range resp.([]interface{}) {
-		oracle := &Oracle{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &oracle)
-		oracles = append(oracles, oracle)
-	}
 	return oracles, nil
 }
 
+// ListOraclesPager returns an *api.Pager which walks all pages of the ListOracles results
+func ListOraclesPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListOraclesPager(params)
+}
+
+// ListOraclesPager returns an *api.Pager which walks all pages of the ListOracles results
+func (s *Service) ListOraclesPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("oracles", params)
+}
+
 // GetOracleDetails
 func GetOracleDetails(token, oracleID string, params map[string]interface{}) (*Oracle, error) {
+	return GetOracleDetailsWithContext(context.Background(), token, oracleID, params)
+}
+
+// GetOracleDetailsWithContext
+func GetOracleDetailsWithContext(ctx context.Context, token, oracleID string, params map[string]interface{}) (*Oracle, error) {
+	return InitNChainService(token).GetOracleDetails(ctx, oracleID, params)
+}
+
+// GetOracleDetails
+func (s *Service) GetOracleDetails(ctx context.Context, oracleID string, params map[string]interface{}) (*Oracle, error) {
 	uri := fmt.Sprintf("oracles/%s", oracleID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	oracle := &Oracle{}
+	status, err := s.GetInto(ctx, uri, params, oracle)
 	if err != nil {
 		return nil, err
 	}
@@ -691,19 +1070,23 @@ func GetOracleDetails(token, oracleID string, params map[string]interface{}) (*O
 		return nil, fmt.Errorf("failed to fetch oracle; status %v", status)
 	}
 
-	oracle := &Oracle{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &oracle)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch oracle; status: %v; %s", status, err.Error())
-	}
-
 	return oracle, nil
 }
 
 // CreateTokenContract
 func CreateTokenContract(token string, params map[string]interface{}) (*Token, error) {
-	status, resp, err := InitNChainService(token).Post("tokens", params)
+	return CreateTokenContractWithContext(context.Background(), token, params)
+}
+
+// CreateTokenContractWithContext
+func CreateTokenContractWithContext(ctx context.Context, token string, params map[string]interface{}) (*Token, error) {
+	return InitNChainService(token).CreateTokenContract(ctx, params)
+}
+
+// CreateTokenContract
+func (s *Service) CreateTokenContract(ctx context.Context, params map[string]interface{}) (*Token, error) {
+	tkn := &Token{}
+	status, err := s.PostInto(ctx, "tokens", params, tkn)
 	if err != nil {
 		return nil, err
 	}
@@ -712,19 +1095,23 @@ func CreateTokenContract(token string, params map[string]interface{}) (*Token, e
 		return nil, fmt.Errorf("failed to create token contract; status: %v", status)
 	}
 
-	tkn := &Token{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &tkn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create token contract; status: %v; %s", status, err.Error())
-	}
-
 	return tkn, nil
 }
 
 // ListTokenContracts
 func ListTokenContracts(token string, params map[string]interface{}) ([]*Token, error) {
-	status, resp, err := InitNChainService(token).Get("tokens", params)
+	return ListTokenContractsWithContext(context.Background(), token, params)
+}
+
+// ListTokenContractsWithContext
+func ListTokenContractsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Token, error) {
+	return InitNChainService(token).ListTokenContracts(ctx, params)
+}
+
+// ListTokenContracts
+func (s *Service) ListTokenContracts(ctx context.Context, params map[string]interface{}) ([]*Token, error) {
+	tknContracts := make([]*Token, 0)
+	status, err := s.GetInto(ctx, "tokens", params, &tknContracts)
 	if err != nil {
 		return nil, err
 	}
@@ -733,20 +1120,34 @@ func ListTokenContracts(token string, params map[string]interface{}) ([]*Token,
 		return nil, fmt.Errorf("failed to list token contracts; status: %v", status)
 	}
 
-	tknContracts := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tknContract := &Token{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tknContract)
-		tknContracts = append(tknContracts, tknContract)
-	}
 	return tknContracts, nil
 }
 
+// ListTokenContractsPager returns an *api.Pager which walks all pages of the ListTokenContracts results
+func ListTokenContractsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListTokenContractsPager(params)
+}
+
+// ListTokenContractsPager returns an *api.Pager which walks all pages of the ListTokenContracts results
+func (s *Service) ListTokenContractsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("tokens", params)
+}
+
 // GetTokenContractDetails
 func GetTokenContractDetails(token, tokenID string, params map[string]interface{}) (*Token, error) {
+	return GetTokenContractDetailsWithContext(context.Background(), token, tokenID, params)
+}
+
+// GetTokenContractDetailsWithContext
+func GetTokenContractDetailsWithContext(ctx context.Context, token, tokenID string, params map[string]interface{}) (*Token, error) {
+	return InitNChainService(token).GetTokenContractDetails(ctx, tokenID, params)
+}
+
+// GetTokenContractDetails
+func (s *Service) GetTokenContractDetails(ctx context.Context, tokenID string, params map[string]interface{}) (*Token, error) {
 	uri := fmt.Sprintf("tokens/%s", tokenID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	tknContract := &Token{}
+	status, err := s.GetInto(ctx, uri, params, tknContract)
 	if err != nil {
 		return nil, err
 	}
@@ -755,19 +1156,23 @@ func GetTokenContractDetails(token, tokenID string, params map[string]interface{
 		return nil, fmt.Errorf("failed to fetch token contract; status %v", status)
 	}
 
-	tknContract := &Token{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &tknContract)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch token contract; status: %v; %s", status, err.Error())
-	}
-
 	return tknContract, nil
 }
 
 // CreateTransaction
 func CreateTransaction(token string, params map[string]interface{}) (*Transaction, error) {
-	status, resp, err := InitNChainService(token).Post("transactions", params)
+	return CreateTransactionWithContext(context.Background(), token, params)
+}
+
+// CreateTransactionWithContext
+func CreateTransactionWithContext(ctx context.Context, token string, params map[string]interface{}) (*Transaction, error) {
+	return InitNChainService(token).CreateTransaction(ctx, params)
+}
+
+// CreateTransaction
+func (s *Service) CreateTransaction(ctx context.Context, params map[string]interface{}) (*Transaction, error) {
+	tx := &Transaction{}
+	status, err := s.PostInto(ctx, "transactions", params, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -776,19 +1181,23 @@ func CreateTransaction(token string, params map[string]interface{}) (*Transactio
 		return nil, fmt.Errorf("failed to create tx; status: %v", status)
 	}
 
-	tx := &Transaction{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &tx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create tx; status: %v; %s", status, err.Error())
-	}
-
 	return tx, nil
 }
 
 // ListTransactions
 func ListTransactions(token string, params map[string]interface{}) ([]*Transaction, error) {
-	status, resp, err := InitNChainService(token).Get("transactions", params)
+	return ListTransactionsWithContext(context.Background(), token, params)
+}
+
+// ListTransactionsWithContext
+func ListTransactionsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Transaction, error) {
+	return InitNChainService(token).ListTransactions(ctx, params)
+}
+
+// ListTransactions
+func (s *Service) ListTransactions(ctx context.Context, params map[string]interface{}) ([]*Transaction, error) {
+	txs := make([]*Transaction, 0)
+	status, err := s.GetInto(ctx, "transactions", params, &txs)
 	if err != nil {
 		return nil, err
 	}
@@ -797,20 +1206,34 @@ func ListTransactions(token string, params map[string]interface{}) ([]*Transacti
 		return nil, fmt.Errorf("failed to list transactions; status: %v", status)
 	}
 
-	txs := make([]*Transaction, 0)
-	for _, item := range resp.([]interface{}) {
-		tx := &Transaction{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tx)
-		txs = append(txs, tx)
-	}
 	return txs, nil
 }
 
+// ListTransactionsPager returns an *api.Pager which walks all pages of the ListTransactions results
+func ListTransactionsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListTransactionsPager(params)
+}
+
+// ListTransactionsPager returns an *api.Pager which walks all pages of the ListTransactions results
+func (s *Service) ListTransactionsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("transactions", params)
+}
+
 // GetTransactionDetails
 func GetTransactionDetails(token, txID string, params map[string]interface{}) (*Transaction, error) {
+	return GetTransactionDetailsWithContext(context.Background(), token, txID, params)
+}
+
+// GetTransactionDetailsWithContext
+func GetTransactionDetailsWithContext(ctx context.Context, token, txID string, params map[string]interface{}) (*Transaction, error) {
+	return InitNChainService(token).GetTransactionDetails(ctx, txID, params)
+}
+
+// GetTransactionDetails
+func (s *Service) GetTransactionDetails(ctx context.Context, txID string, params map[string]interface{}) (*Transaction, error) {
 	uri := fmt.Sprintf("transactions/%s", txID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	tx := &Transaction{}
+	status, err := s.GetInto(ctx, uri, params, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -819,19 +1242,23 @@ func GetTransactionDetails(token, txID string, params map[string]interface{}) (*
 		return nil, fmt.Errorf("failed to fetch tx; status %v", status)
 	}
 
-	tx := &Transaction{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &tx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tx; status: %v; %s", status, err.Error())
-	}
-
 	return tx, nil
 }
 
 // CreateWallet
 func CreateWallet(token string, params map[string]interface{}) (*Wallet, error) {
-	status, resp, err := InitNChainService(token).Post("wallets", params)
+	return CreateWalletWithContext(context.Background(), token, params)
+}
+
+// CreateWalletWithContext
+func CreateWalletWithContext(ctx context.Context, token string, params map[string]interface{}) (*Wallet, error) {
+	return InitNChainService(token).CreateWallet(ctx, params)
+}
+
+// CreateWallet
+func (s *Service) CreateWallet(ctx context.Context, params map[string]interface{}) (*Wallet, error) {
+	wallet := &Wallet{}
+	status, err := s.PostInto(ctx, "wallets", params, wallet)
 	if err != nil {
 		return nil, err
 	}
@@ -840,19 +1267,23 @@ func CreateWallet(token string, params map[string]interface{}) (*Wallet, error)
 		return nil, fmt.Errorf("failed to create wallet; status: %v", status)
 	}
 
-	wallet := &Wallet{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &wallet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create wallet; status: %v; %s", status, err.Error())
-	}
-
 	return wallet, nil
 }
 
 // ListWallets
 func ListWallets(token string, params map[string]interface{}) ([]*Wallet, error) {
-	status, resp, err := InitNChainService(token).Get("wallets", params)
+	return ListWalletsWithContext(context.Background(), token, params)
+}
+
+// ListWalletsWithContext
+func ListWalletsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Wallet, error) {
+	return InitNChainService(token).ListWallets(ctx, params)
+}
+
+// ListWallets
+func (s *Service) ListWallets(ctx context.Context, params map[string]interface{}) ([]*Wallet, error) {
+	wallets := make([]*Wallet, 0)
+	status, err := s.GetInto(ctx, "wallets", params, &wallets)
 	if err != nil {
 		return nil, err
 	}
@@ -861,20 +1292,34 @@ func ListWallets(token string, params map[string]interface{}) ([]*Wallet, error)
 		return nil, fmt.Errorf("failed to list wallets; status: %v", status)
 	}
 
-	wallets := make([]*Wallet, 0)
-	for _, item := range resp.([]interface{}) {
-		wallet := &Wallet{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &wallet)
-		wallets = append(wallets, wallet)
-	}
 	return wallets, nil
 }
 
+// ListWalletsPager returns an *api.Pager which walks all pages of the ListWallets results
+func ListWalletsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListWalletsPager(params)
+}
+
+// ListWalletsPager returns an *api.Pager which walks all pages of the ListWallets results
+func (s *Service) ListWalletsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("wallets", params)
+}
+
 // GetWalletDetails
 func GetWalletDetails(token, walletID string, params map[string]interface{}) (*Wallet, error) {
+	return GetWalletDetailsWithContext(context.Background(), token, walletID, params)
+}
+
+// GetWalletDetailsWithContext
+func GetWalletDetailsWithContext(ctx context.Context, token, walletID string, params map[string]interface{}) (*Wallet, error) {
+	return InitNChainService(token).GetWalletDetails(ctx, walletID, params)
+}
+
+// GetWalletDetails
+func (s *Service) GetWalletDetails(ctx context.Context, walletID string, params map[string]interface{}) (*Wallet, error) {
 	uri := fmt.Sprintf("wallets/%s", walletID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	wallet := &Wallet{}
+	status, err := s.GetInto(ctx, uri, params, wallet)
 	if err != nil {
 		return nil, err
 	}
@@ -883,20 +1328,24 @@ func GetWalletDetails(token, walletID string, params map[string]interface{}) (*W
 		return nil, fmt.Errorf("failed to fetch wallet; status %v", status)
 	}
 
-	wallet := &Wallet{}
-	raw, _ := json.Marshal(resp)
-	err = json.Unmarshal(raw, &wallet)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch wallet; status: %v; %s", status, err.Error())
-	}
-
 	return wallet, nil
 }
 
 // ListWalletAccounts
 func ListWalletAccounts(token, walletID string, params map[string]interface{}) ([]*Account, error) {
+	return ListWalletAccountsWithContext(context.Background(), token, walletID, params)
+}
+
+// ListWalletAccountsWithContext
+func ListWalletAccountsWithContext(ctx context.Context, token, walletID string, params map[string]interface{}) ([]*Account, error) {
+	return InitNChainService(token).ListWalletAccounts(ctx, walletID, params)
+}
+
+// ListWalletAccounts
+func (s *Service) ListWalletAccounts(ctx context.Context, walletID string, params map[string]interface{}) ([]*Account, error) {
 	uri := fmt.Sprintf("wallets/%s/accounts", walletID)
-	status, resp, err := InitNChainService(token).Get(uri, params)
+	accounts := make([]*Account, 0)
+	status, err := s.GetInto(ctx, uri, params, &accounts)
 	if err != nil {
 		return nil, err
 	}
@@ -905,12 +1354,16 @@ func ListWalletAccounts(token, walletID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list accounts; status: %v", status)
 	}
 
-	accounts := make([]*Account, 0)
-	for _, item := range resp.([]interface{}) {
-		account := &Account{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &account)
-		accounts = append(accounts, account)
-	}
 	return accounts, nil
 }
+
+// ListWalletAccountsPager returns an *api.Pager which walks all pages of the ListWalletAccounts results
+func ListWalletAccountsPager(token, walletID string, params map[string]interface{}) *api.Pager {
+	return InitNChainService(token).ListWalletAccountsPager(walletID, params)
+}
+
+// ListWalletAccountsPager returns an *api.Pager which walks all pages of the ListWalletAccounts results
+func (s *Service) ListWalletAccountsPager(walletID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("wallets/%s/accounts", walletID)
+	return s.Pager(uri, params)
+}
@@ -0,0 +1,48 @@
+package nchain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api/fake"
+	"github.com/provideplatform/provide-go/api/nchain"
+)
+
+func TestListBridgesAndBlocks(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	defer srv.Setenv()()
+
+	token := srv.Token()
+	network, err := nchain.CreateNetwork(token, map[string]interface{}{"name": "testnet"})
+	if err != nil {
+		t.Fatalf("failed to create network; %s", err.Error())
+	}
+	networkID := network.ID.String()
+
+	for _, name := range []string{"a", "b", "c"} {
+		status, _, err := nchain.CreateBridge(token, map[string]interface{}{"name": name, "network_id": networkID})
+		if err != nil || status != 201 {
+			t.Fatalf("failed to create bridge; %d, %v", status, err)
+		}
+	}
+
+	bridges, err := nchain.ListNetworkBridges(token, networkID, nil)
+	if err != nil || len(bridges) != 3 || bridges[0].NetworkID != network.ID {
+		t.Fatalf("expected network bridges; got %v, %v", bridges, err)
+	}
+
+	pager := nchain.ListBridgesPager(token, map[string]interface{}{"rpp": 2})
+	all := make([]*nchain.Bridge, 0)
+	if err := pager.All(context.Background(), &all); err != nil {
+		t.Fatalf("failed to page bridges; %s", err.Error())
+	}
+	if len(all) != 3 || *all[2].Name != "c" {
+		t.Errorf("expected all bridges to be paged; got %d", len(all))
+	}
+
+	blocks, err := nchain.ListNetworkBlocks(token, networkID, nil)
+	if err != nil || len(blocks) != 0 {
+		t.Errorf("expected no network blocks; got %v, %v", blocks, err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package otel adapts OpenTelemetry to the Tracer and Metrics of an api.Client, so the requests
+// sent by the service clients appear as client spans in distributed traces and are measured by
+// the http.client.request.duration histogram.
+//
+//	tracer := otel.NewTracer(nil)
+//	metrics, err := otel.NewMetrics(nil)
+//	...
+//	api.SetTracer(tracer)
+//	api.SetMetrics(metrics)
+//
+// The global tracer and meter providers are used when nil providers are given.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/provideplatform/provide-go/api"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter which instrument the api.Client
+const InstrumentationName = "github.com/provideplatform/provide-go/api"
+
+// RequestDurationMetric is the name of the histogram of request durations, in seconds
+const RequestDurationMetric = "http.client.request.duration"
+
+// Tracer implements api.Tracer using an OpenTelemetry tracer; spans are started as client spans
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a *Tracer which starts spans using the given provider, or the global
+// tracer provider if it is nil
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = global.GetTracerProvider()
+	}
+	return &Tracer{
+		tracer: provider.Tracer(InstrumentationName),
+	}
+}
+
+// Start starts a client span having the given name and attributes
+func (t *Tracer) Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, api.Span) {
+	ctx, s := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes(attrs)...),
+	)
+	return ctx, &span{span: s}
+}
+
+// span adapts an OpenTelemetry span to api.Span
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SpanContext() api.SpanContext {
+	sc := s.span.SpanContext()
+	return api.SpanContext{
+		TraceID:    sc.TraceID(),
+		SpanID:     sc.SpanID(),
+		TraceFlags: byte(sc.TraceFlags()),
+		TraceState: sc.TraceState().String(),
+	}
+}
+
+func (s *span) SetAttributes(attrs map[string]interface{}) {
+	s.span.SetAttributes(attributes(attrs)...)
+}
+
+func (s *span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.span.End()
+}
+
+// Metrics implements api.Metrics by recording the duration of each request in an OpenTelemetry
+// histogram, using api.DefaultLatencyBuckets as its bucket boundaries
+type Metrics struct {
+	duration metric.Float64Histogram
+}
+
+// NewMetrics returns a *Metrics which records measurements using the given provider, or the
+// global meter provider if it is nil
+func NewMetrics(provider metric.MeterProvider) (*Metrics, error) {
+	if provider == nil {
+		provider = global.GetMeterProvider()
+	}
+
+	duration, err := provider.Meter(InstrumentationName).Float64Histogram(
+		RequestDurationMetric,
+		metric.WithDescription("Duration of HTTP client requests, including retries"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(api.DefaultLatencyBuckets...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s histogram; %s", RequestDurationMetric, err.Error())
+	}
+
+	return &Metrics{
+		duration: duration,
+	}, nil
+}
+
+// RecordRequest records the duration of the request, attributed with its method, route,
+// service, status and error type
+func (m *Metrics) RecordRequest(ctx context.Context, measurement *api.RequestMeasurement) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", measurement.Method),
+		attribute.String("http.route", measurement.Route),
+		attribute.String("peer.service", measurement.Service),
+	}
+	if measurement.Status != 0 {
+		attrs = append(attrs, attribute.Int("http.response.status_code", measurement.Status))
+	}
+	if errorType := measurement.ErrorType(); errorType != "" {
+		attrs = append(attrs, attribute.String("error.type", errorType))
+	}
+
+	m.duration.Record(ctx, measurement.Duration.Seconds(), metric.WithAttributes(attrs...))
+}
+
+// attributes converts the given attributes, ordered by key, to OpenTelemetry attributes
+func attributes(attrs map[string]interface{}) []attribute.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		switch val := attrs[key].(type) {
+		case string:
+			kvs = append(kvs, attribute.String(key, val))
+		case bool:
+			kvs = append(kvs, attribute.Bool(key, val))
+		case int:
+			kvs = append(kvs, attribute.Int(key, val))
+		case int64:
+			kvs = append(kvs, attribute.Int64(key, val))
+		case float64:
+			kvs = append(kvs, attribute.Float64(key, val))
+		default:
+			kvs = append(kvs, attribute.String(key, fmt.Sprint(val)))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,91 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracerAndMetrics(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	metrics, err := NewMetrics(meterProvider)
+	if err != nil {
+		t.Fatalf("failed to initialize metrics; %s", err.Error())
+	}
+
+	config := api.ConfigFromEnv("NCHAIN", "", "", "")
+	err = config.Apply(
+		api.WithURL(srv.URL+"/api/v1"),
+		api.WithTracer(NewTracer(tracerProvider)),
+		api.WithMetrics(metrics),
+		api.WithRetryPolicy(&api.RetryPolicy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		t.Fatalf("failed to configure client; %s", err.Error())
+	}
+	client := config.Client()
+	client.Get("networks/8f3ac6a4-4b7c-4c47-b1a5-52a4b6e6a1b4", nil)
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span; got %d", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "GET networks/{id}" || span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("unexpected span: %s (%s)", span.Name(), span.SpanKind())
+	}
+	expected := api.SpanContext{TraceID: span.SpanContext().TraceID(), SpanID: span.SpanContext().SpanID(), TraceFlags: 1}
+	if traceparent != expected.Traceparent() {
+		t.Errorf("expected span context to be propagated; got traceparent %s", traceparent)
+	}
+	if !hasAttribute(span.Attributes(), attribute.Int("http.response.status_code", 404)) || !hasAttribute(span.Attributes(), attribute.String("peer.service", "nchain")) {
+		t.Errorf("unexpected span attributes: %v", span.Attributes())
+	}
+	if span.Status().Code.String() != "Error" {
+		t.Errorf("expected span to record the error; got status %v", span.Status())
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics; %s", err.Error())
+	}
+	if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 {
+		t.Fatalf("expected a single metric; got %+v", rm.ScopeMetrics)
+	}
+	histogram, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+	if !ok || len(histogram.DataPoints) != 1 || histogram.DataPoints[0].Count != 1 {
+		t.Fatalf("expected a single measurement; got %+v", rm.ScopeMetrics[0].Metrics[0].Data)
+	}
+	if errorType, _ := histogram.DataPoints[0].Attributes.Value("error.type"); errorType.AsString() != "404" {
+		t.Errorf("expected error.type 404; got %v", errorType.AsString())
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, kv attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == kv {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultPagerResultsPerPage = 25
+const totalResultsCountHeader = "X-Total-Results-Count"
+
+// Pager walks the pages of a paginated list endpoint using the page and rpp query parameters;
+// the total number of results reported by the API is exposed once the first page is fetched
+type Pager struct {
+	client *Client
+	uri    string
+	params map[string]interface{}
+
+	page int
+	rpp  int
+
+	fetched      uint64
+	totalResults *uint64
+	done         bool
+}
+
+// Pager returns a *Pager for the list endpoint at the given uri; page and rpp parameters, when
+// present in the given params, determine the first page and the number of results per page
+func (c *Client) Pager(uri string, params map[string]interface{}) *Pager {
+	p := &Pager{
+		client: c,
+		uri:    uri,
+		params: map[string]interface{}{},
+		page:   1,
+		rpp:    defaultPagerResultsPerPage,
+	}
+
+	for key, val := range params {
+		p.params[key] = val
+	}
+
+	if page, ok := pagerParam(params, "page"); ok && page > 0 {
+		p.page = page
+	}
+
+	if rpp, ok := pagerParam(params, "rpp"); ok && rpp > 0 {
+		p.rpp = rpp
+	}
+
+	return p
+}
+
+// Next fetches the next page and decodes its items into target, which must be a pointer to
+// a slice; the slice is replaced by the items on the page. Returns false, without fetching,
+// once all pages have been walked.
+func (p *Pager) Next(ctx context.Context, target interface{}) (bool, error) {
+	if p.done {
+		return false, nil
+	}
+
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Slice {
+		return false, errors.New("pager target must be a pointer to a slice")
+	}
+
+	p.params["page"] = strconv.Itoa(p.page)
+	p.params["rpp"] = strconv.Itoa(p.rpp)
+
+	url := p.client.buildURL(p.uri)
+	resp, err := p.client.sendRequest(ctx, "GET", url, defaultContentType, p.params)
+	if err != nil {
+		return false, err
+	}
+
+	page := reflect.New(val.Elem().Type())
+	_, err = p.client.decodeResponse(resp, page.Interface())
+	if err != nil {
+		return false, err
+	}
+	val.Elem().Set(page.Elem())
+
+	if totalResultsCount := resp.Header.Get(totalResultsCountHeader); totalResultsCount != "" {
+		totalResults, err := strconv.ParseUint(totalResultsCount, 10, 64)
+		if err != nil {
+			common.Log.Debugf("failed to parse %s header: %s; %s", totalResultsCountHeader, totalResultsCount, err.Error())
+		} else {
+			p.totalResults = &totalResults
+		}
+	}
+
+	n := page.Elem().Len()
+	p.fetched += uint64(n)
+	p.page++
+
+	// the API may cap rpp, so a short page only ends the walk when the total is unknown and
+	// the page is empty
+	if n == 0 || (p.totalResults != nil && p.fetched >= *p.totalResults) {
+		p.done = true
+	}
+
+	return n > 0, nil
+}
+
+// All fetches all remaining pages and appends their items to target, which must be a pointer
+// to a slice
+func (p *Pager) All(ctx context.Context, target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Slice {
+		return errors.New("pager target must be a pointer to a slice")
+	}
+
+	page := reflect.New(val.Elem().Type())
+	for {
+		ok, err := p.Next(ctx, page.Interface())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		val.Elem().Set(reflect.AppendSlice(val.Elem(), page.Elem()))
+	}
+
+	return nil
+}
+
+// Page returns the number of the page which will be fetched by the next call to Next
+func (p *Pager) Page() int {
+	return p.page
+}
+
+// TotalResults returns the total number of results reported by the API, or nil if the first
+// page has not yet been fetched or the API did not report a total
+func (p *Pager) TotalResults() *uint64 {
+	return p.totalResults
+}
+
+func pagerParam(params map[string]interface{}, name string) (int, bool) {
+	switch val := params[name].(type) {
+	case int:
+		return val, true
+	case string:
+		i, err := strconv.Atoi(val)
+		return i, err == nil
+	}
+	return 0, false
+}
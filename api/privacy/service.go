@@ -1,9 +1,8 @@
 package privacy
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -18,36 +17,50 @@ type Service struct {
 	api.Client
 }
 
-// InitPrivacyService convenience method to initialize a `privacy.Service` instance
-func InitPrivacyService(token string) *Service {
-	host := defaultPrivacyHost
-	if os.Getenv("PRIVACY_API_HOST") != "" {
-		host = os.Getenv("PRIVACY_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the privacy api from the PRIVACY_API_HOST,
+// PRIVACY_API_PATH and PRIVACY_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("PRIVACY", defaultPrivacyHost, defaultPrivacyPath, defaultPrivacyScheme)
+}
 
-	path := defaultPrivacyPath
-	if os.Getenv("PRIVACY_API_PATH") != "" {
-		host = os.Getenv("PRIVACY_API_PATH")
+// NewService initializes a `privacy.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultPrivacyScheme
-	if os.Getenv("PRIVACY_API_SCHEME") != "" {
-		scheme = os.Getenv("PRIVACY_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  common.StringOrNil(token),
-		},
-	}
+// NewServiceWithConfig initializes a `privacy.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitPrivacyService convenience method to initialize a `privacy.Service` instance
+func InitPrivacyService(token string) *Service {
+	config := ConfigFromEnv()
+	config.Token = common.StringOrNil(token)
+	return NewServiceWithConfig(config)
 }
 
 // ListCircuits lists the circuits in the scope of the given bearer token
 func ListCircuits(token string, params map[string]interface{}) ([]*Circuit, error) {
-	status, resp, err := InitPrivacyService(token).Get("circuits", params)
+	return ListCircuitsWithContext(context.Background(), token, params)
+}
+
+// ListCircuitsWithContext lists the circuits in the scope of the given bearer token
+func ListCircuitsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Circuit, error) {
+	return InitPrivacyService(token).ListCircuits(ctx, params)
+}
+
+// ListCircuits lists the circuits in the scope of the given bearer token
+func (s *Service) ListCircuits(ctx context.Context, params map[string]interface{}) ([]*Circuit, error) {
+	circuits := make([]*Circuit, 0)
+	status, err := s.GetInto(ctx, "circuits", params, &circuits)
 	if err != nil {
 		return nil, err
 	}
@@ -56,21 +69,34 @@ func ListCircuits(token string, params map[string]interface{}) ([]*Circuit, erro
 		return nil, fmt.Errorf("failed to list circuits; status: %v", status)
 	}
 
-	circuits := make([]*Circuit, 0)
-	for _, item := range resp.([]interface{}) {
-		circuit := &Circuit{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &circuit)
-		circuits = append(circuits, circuit)
-	}
-
 	return circuits, nil
 }
 
+// ListCircuitsPager returns an *api.Pager which walks all pages of the ListCircuits results
+func ListCircuitsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitPrivacyService(token).ListCircuitsPager(params)
+}
+
+// ListCircuitsPager returns an *api.Pager which walks all pages of the ListCircuits results
+func (s *Service) ListCircuitsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("circuits", params)
+}
+
 // GetCircuitDetails fetches details for the given circuit
 func GetCircuitDetails(token, circuitID string) (*Circuit, error) {
+	return GetCircuitDetailsWithContext(context.Background(), token, circuitID)
+}
+
+// GetCircuitDetailsWithContext fetches details for the given circuit
+func GetCircuitDetailsWithContext(ctx context.Context, token, circuitID string) (*Circuit, error) {
+	return InitPrivacyService(token).GetCircuitDetails(ctx, circuitID)
+}
+
+// GetCircuitDetails fetches details for the given circuit
+func (s *Service) GetCircuitDetails(ctx context.Context, circuitID string) (*Circuit, error) {
 	uri := fmt.Sprintf("circuits/%s", circuitID)
-	status, resp, err := InitPrivacyService(token).Get(uri, map[string]interface{}{})
+	circuit := &Circuit{}
+	status, err := s.GetInto(ctx, uri, map[string]interface{}{}, circuit)
 	if err != nil {
 		return nil, err
 	}
@@ -79,16 +105,23 @@ func GetCircuitDetails(token, circuitID string) (*Circuit, error) {
 		return nil, fmt.Errorf("failed to fetch circuit; status: %v", status)
 	}
 
-	circuit := &Circuit{}
-	raw, _ := json.Marshal(resp)
-	json.Unmarshal(raw, &circuit)
-
 	return circuit, nil
 }
 
 // CreateCircuit creates a new circuit in the registry
 func CreateCircuit(token string, params map[string]interface{}) (*Circuit, error) {
-	status, resp, err := InitPrivacyService(token).Post("circuits", params)
+	return CreateCircuitWithContext(context.Background(), token, params)
+}
+
+// CreateCircuitWithContext creates a new circuit in the registry
+func CreateCircuitWithContext(ctx context.Context, token string, params map[string]interface{}) (*Circuit, error) {
+	return InitPrivacyService(token).CreateCircuit(ctx, params)
+}
+
+// CreateCircuit creates a new circuit in the registry
+func (s *Service) CreateCircuit(ctx context.Context, params map[string]interface{}) (*Circuit, error) {
+	circuit := &Circuit{}
+	status, err := s.PostInto(ctx, "circuits", params, circuit)
 	if err != nil {
 		return nil, err
 	}
@@ -97,17 +130,24 @@ func CreateCircuit(token string, params map[string]interface{}) (*Circuit, error
 		return nil, fmt.Errorf("failed to create circuit; status: %v", status)
 	}
 
-	circuit := &Circuit{}
-	raw, _ := json.Marshal(resp)
-	json.Unmarshal(raw, &circuit)
-
 	return circuit, nil
 }
 
 // Prove generates a proof using the given inputs for the named circuit
 func Prove(token, circuitID string, params map[string]interface{}) (*ProveResponse, error) {
+	return ProveWithContext(context.Background(), token, circuitID, params)
+}
+
+// ProveWithContext generates a proof using the given inputs for the named circuit
+func ProveWithContext(ctx context.Context, token, circuitID string, params map[string]interface{}) (*ProveResponse, error) {
+	return InitPrivacyService(token).Prove(ctx, circuitID, params)
+}
+
+// Prove generates a proof using the given inputs for the named circuit
+func (s *Service) Prove(ctx context.Context, circuitID string, params map[string]interface{}) (*ProveResponse, error) {
 	uri := fmt.Sprintf("circuits/%s/prove", circuitID)
-	status, resp, err := InitPrivacyService(token).Post(uri, params)
+	prove := &ProveResponse{}
+	status, err := s.PostInto(ctx, uri, params, prove)
 	if err != nil {
 		return nil, err
 	}
@@ -116,17 +156,24 @@ func Prove(token, circuitID string, params map[string]interface{}) (*ProveRespon
 		return nil, fmt.Errorf("failed to generate proof; status: %v", status)
 	}
 
-	prove := &ProveResponse{}
-	raw, _ := json.Marshal(resp)
-	json.Unmarshal(raw, &prove)
-
 	return prove, nil
 }
 
 // Verify verifies the given inputs using the named circuit
 func Verify(token, circuitID string, params map[string]interface{}) (*VerificationResponse, error) {
+	return VerifyWithContext(context.Background(), token, circuitID, params)
+}
+
+// VerifyWithContext verifies the given inputs using the named circuit
+func VerifyWithContext(ctx context.Context, token, circuitID string, params map[string]interface{}) (*VerificationResponse, error) {
+	return InitPrivacyService(token).Verify(ctx, circuitID, params)
+}
+
+// Verify verifies the given inputs using the named circuit
+func (s *Service) Verify(ctx context.Context, circuitID string, params map[string]interface{}) (*VerificationResponse, error) {
 	uri := fmt.Sprintf("circuits/%s/verify", circuitID)
-	status, resp, err := InitPrivacyService(token).Post(uri, params)
+	verification := &VerificationResponse{}
+	status, err := s.PostInto(ctx, uri, params, verification)
 	if err != nil {
 		return nil, err
 	}
@@ -135,17 +182,24 @@ func Verify(token, circuitID string, params map[string]interface{}) (*Verificati
 		return nil, fmt.Errorf("failed to verify circuit inputs; status: %v", status)
 	}
 
-	verification := &VerificationResponse{}
-	raw, _ := json.Marshal(resp)
-	json.Unmarshal(raw, &verification)
-
 	return verification, nil
 }
 
 // GetNoteValue fetches the value in the note store at a specified index
 func GetNoteValue(token, circuitID string, index uint64) (*StoreValueResponse, error) {
+	return GetNoteValueWithContext(context.Background(), token, circuitID, index)
+}
+
+// GetNoteValueWithContext fetches the value in the note store at a specified index
+func GetNoteValueWithContext(ctx context.Context, token, circuitID string, index uint64) (*StoreValueResponse, error) {
+	return InitPrivacyService(token).GetNoteValue(ctx, circuitID, index)
+}
+
+// GetNoteValue fetches the value in the note store at a specified index
+func (s *Service) GetNoteValue(ctx context.Context, circuitID string, index uint64) (*StoreValueResponse, error) {
 	uri := fmt.Sprintf("circuits/%s/notes/%d", circuitID, index)
-	status, resp, err := InitPrivacyService(token).Get(uri, map[string]interface{}{})
+	val := &StoreValueResponse{}
+	status, err := s.GetInto(ctx, uri, map[string]interface{}{}, val)
 	if err != nil {
 		return nil, err
 	}
@@ -154,17 +208,24 @@ func GetNoteValue(token, circuitID string, index uint64) (*StoreValueResponse, e
 		return nil, fmt.Errorf("failed to fetch note value at index %d; status: %v", index, status)
 	}
 
-	val := &StoreValueResponse{}
-	raw, _ := json.Marshal(resp)
-	json.Unmarshal(raw, &val)
-
 	return val, nil
 }
 
 // GetNullifierValue fetches the value in the nullifier store at the specified key
 func GetNullifierValue(token, circuitID, key string) (*StoreValueResponse, error) {
+	return GetNullifierValueWithContext(context.Background(), token, circuitID, key)
+}
+
+// GetNullifierValueWithContext fetches the value in the nullifier store at the specified key
+func GetNullifierValueWithContext(ctx context.Context, token, circuitID, key string) (*StoreValueResponse, error) {
+	return InitPrivacyService(token).GetNullifierValue(ctx, circuitID, key)
+}
+
+// GetNullifierValue fetches the value in the nullifier store at the specified key
+func (s *Service) GetNullifierValue(ctx context.Context, circuitID, key string) (*StoreValueResponse, error) {
 	uri := fmt.Sprintf("circuits/%s/nullifiers/%s", circuitID, key)
-	status, resp, err := InitPrivacyService(token).Get(uri, map[string]interface{}{})
+	val := &StoreValueResponse{}
+	status, err := s.GetInto(ctx, uri, map[string]interface{}{}, val)
 	if err != nil {
 		return nil, err
 	}
@@ -173,9 +234,5 @@ func GetNullifierValue(token, circuitID, key string) (*StoreValueResponse, error
 		return nil, fmt.Errorf("failed to fetch note nullifier with key %s; status: %v", key, status)
 	}
 
-	val := &StoreValueResponse{}
-	raw, _ := json.Marshal(resp)
-	json.Unmarshal(raw, &val)
-
 	return val, nil
 }
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of requests sent to each host using a token bucket; a single
+// limiter may be shared by many clients, in which case the limit applies to their combined
+// requests. Requests wait for a token, or until their context is done.
+type RateLimiter struct {
+	// Rate is the number of requests per second permitted to each host; requests are not
+	// limited when it is not positive
+	Rate float64
+
+	// Burst is the maximum number of requests which may be sent to a host at once
+	Burst int
+
+	buckets map[string]*tokenBucket
+	mutex   sync.Mutex
+}
+
+// RateLimitStatus describes the state of the token bucket of a host
+type RateLimitStatus struct {
+	Host    string  `json:"host"`
+	Tokens  float64 `json:"tokens"`
+	Waiting int     `json:"waiting"`
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	waiting int
+}
+
+// NewRateLimiter returns a *RateLimiter permitting rate requests per second to each host, with
+// bursts of up to burst requests; burst is at least 1
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		Rate:    rate,
+		Burst:   burst,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// Wait blocks until a request may be sent to the given host, or returns the error of the
+// context if it is done first
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	if l.Rate <= 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	bucket := l.bucket(host)
+	delay := l.reserve(bucket)
+	if delay <= 0 {
+		l.mutex.Unlock()
+		return nil
+	}
+	bucket.waiting++
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		l.mutex.Lock()
+		bucket.waiting--
+		l.mutex.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		bucket.waiting--
+		bucket.tokens++
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Status returns the state of the token bucket of each host to which a request has been sent
+func (l *RateLimiter) Status() []*RateLimitStatus {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	status := make([]*RateLimitStatus, 0, len(l.buckets))
+	for host, bucket := range l.buckets {
+		l.refill(bucket)
+		status = append(status, &RateLimitStatus{
+			Host:    host,
+			Tokens:  math.Max(bucket.tokens, 0),
+			Waiting: bucket.waiting,
+		})
+	}
+	return status
+}
+
+// middleware returns middleware which waits for the limiter before each attempt of a request
+func (l *RateLimiter) middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := l.Wait(req.Context(), req.URL.Host); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func (l *RateLimiter) bucket(host string) *tokenBucket {
+	if l.buckets == nil {
+		l.buckets = map[string]*tokenBucket{}
+	}
+
+	bucket, ok := l.buckets[host]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:  float64(l.burst()),
+			updated: time.Now(),
+		}
+		l.buckets[host] = bucket
+	}
+	return bucket
+}
+
+// reserve takes a token from the bucket, which may leave it in debt, and returns the delay
+// until the token is available
+func (l *RateLimiter) reserve(bucket *tokenBucket) time.Duration {
+	l.refill(bucket)
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / l.Rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(bucket *tokenBucket) {
+	now := time.Now()
+	bucket.tokens = math.Min(bucket.tokens+now.Sub(bucket.updated).Seconds()*l.Rate, float64(l.burst()))
+	bucket.updated = now
+}
+
+func (l *RateLimiter) burst() int {
+	if l.Burst < 1 {
+		return 1
+	}
+	return l.Burst
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"io"
+	mathrand "math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultRetryInitialBackoff = time.Millisecond * 250
+const defaultRetryMaxAttempts = 3
+const defaultRetryMaxBackoff = time.Second * 5
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+var (
+	envRetryPolicy     *RetryPolicy
+	envRetryPolicyOnce sync.Once
+)
+
+// RetryPolicy configures if and how an api.Client retries a request which failed with a
+// transient error; by default, only idempotent requests (i.e., GET, HEAD, OPTIONS, PUT and
+// DELETE) are retried. When IdempotencyKeys is true, an auto-generated Idempotency-Key header
+// is attached to all other requests (i.e., POST and PATCH), which makes them safe to retry.
+// Requests for which the caller has configured an Idempotency-Key header are always eligible
+// for retry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the initial request
+	MaxAttempts int
+
+	// InitialBackoff is the upper bound of the randomized delay before the first retry; it doubles
+	// with each subsequent attempt until it reaches MaxBackoff
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum upper bound of the randomized delay between attempts, and the
+	// maximum delay requested using Retry-After which is honored; a request is not retried when
+	// the server asks the client to wait longer
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes are the HTTP status codes which are considered transient
+	RetryableStatusCodes []int
+
+	// IdempotencyKeys enables retries of non-idempotent requests by attaching an auto-generated
+	// Idempotency-Key header to each such request
+	IdempotencyKeys bool
+}
+
+// DefaultRetryPolicy returns a retry policy which makes up to 3 attempts of idempotent requests
+// which fail with a connection error, a timeout or a 429, 502, 503 or 504 status
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          defaultRetryMaxAttempts,
+		InitialBackoff:       defaultRetryInitialBackoff,
+		MaxBackoff:           defaultRetryMaxBackoff,
+		RetryableStatusCodes: defaultRetryableStatusCodes,
+	}
+}
+
+// retryPolicy returns the retry policy configured on the client, or the policy configured
+// in the environment using REQUEST_RETRY_MAX_ATTEMPTS and REQUEST_RETRY_IDEMPOTENCY_KEYS;
+// requests are not retried when neither has been configured
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
+	}
+
+	envRetryPolicyOnce.Do(func() {
+		envRetryPolicy = DefaultRetryPolicy()
+		envRetryPolicy.MaxAttempts = 1
+
+		if os.Getenv("REQUEST_RETRY_MAX_ATTEMPTS") != "" {
+			maxAttempts, err := strconv.Atoi(os.Getenv("REQUEST_RETRY_MAX_ATTEMPTS"))
+			if err != nil {
+				common.Log.Debugf("error parsing REQUEST_RETRY_MAX_ATTEMPTS; requests will not be retried; %s", err.Error())
+			} else {
+				common.Log.Debugf("using max attempts of %d for retryable requests", maxAttempts)
+				envRetryPolicy.MaxAttempts = maxAttempts
+			}
+		}
+
+		envRetryPolicy.IdempotencyKeys = strings.ToLower(os.Getenv("REQUEST_RETRY_IDEMPOTENCY_KEYS")) == "true"
+	})
+
+	return envRetryPolicy
+}
+
+// shouldRetry returns true if the given response or error is considered transient
+func (p *RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isTransientError(err)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = defaultRetryableStatusCodes
+	}
+
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTransientError returns true if the given transport error is a timeout, or the failure
+// to establish or keep a connection; certificate verification errors, including those of
+// pinned public keys, and context and circuit breaker errors are never transient
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var certificateInvalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &unknownAuthorityErr) || errors.As(err, &certificateInvalidErr) || errors.As(err, &hostnameErr) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns the delay before the next attempt; the delay is randomized using "full jitter"
+// unless the server has asked the client to wait for a specific duration using Retry-After, in
+// which case false is returned if the requested delay exceeds MaxBackoff
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= maxBackoff
+		}
+	}
+
+	initialBackoff := p.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = defaultRetryInitialBackoff
+	}
+
+	ceiling := initialBackoff
+	for i := 1; i < attempt && ceiling < maxBackoff; i++ {
+		ceiling *= 2
+	}
+	if ceiling > maxBackoff {
+		ceiling = maxBackoff
+	}
+
+	return time.Duration(mathrand.Int63n(int64(ceiling) + 1)), true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number
+// of seconds or an HTTP date
+func parseRetryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(val, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(val); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func generateIdempotencyKey() (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentTypeNDJSON is the media type of newline-delimited JSON streams
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// ContentTypeEventStream is the media type of server-sent event streams
+const ContentTypeEventStream = "text/event-stream"
+
+// Stream is a response body which is read incrementally; callers must Close it
+type Stream struct {
+	io.ReadCloser
+	Status int
+	Header http.Header
+}
+
+// ContentType returns the media type of the stream, without parameters
+func (s *Stream) ContentType() string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(s.Header.Get("Content-Type"), ";")[0]))
+}
+
+// NDJSON returns a decoder which reads the stream as newline-delimited JSON
+func (s *Stream) NDJSON() *NDJSONDecoder {
+	return NewNDJSONDecoder(s)
+}
+
+// Events returns a decoder which reads the stream as server-sent events
+func (s *Stream) Events() *EventDecoder {
+	return NewEventDecoder(s)
+}
+
+// GetStream sends an API GET request and returns its response body as a *Stream rather than
+// reading it into memory; the accept parameter, if non-empty, replaces the default Accept
+// header. Streams are not bound by the default request timeout, only by the given context and
+// the client's Timeout, if set. An *APIError is returned for 4xx and 5xx responses.
+func (c *Client) GetStream(ctx context.Context, uri string, params map[string]interface{}, accept string) (*Stream, error) {
+	return c.sendStreamRequest(ctx, "GET", uri, params, accept)
+}
+
+// PostStream sends an API POST request and returns its response body as a *Stream; see GetStream
+func (c *Client) PostStream(ctx context.Context, uri string, params map[string]interface{}, accept string) (*Stream, error) {
+	return c.sendStreamRequest(ctx, "POST", uri, params, accept)
+}
+
+func (c *Client) sendStreamRequest(ctx context.Context, method, uri string, params map[string]interface{}, accept string) (*Stream, error) {
+	url := c.buildURL(uri)
+	resp, err := c.send(ctx, method, url, defaultContentType, params, &requestOptions{
+		accept:    accept,
+		streaming: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		body, err := c.readResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return nil, NewAPIError(resp, body)
+	}
+
+	body := resp.Body
+	if body == nil {
+		body = http.NoBody
+	} else {
+		body, err = ResponseReader(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Stream{
+		ReadCloser: body,
+		Status:     resp.StatusCode,
+		Header:     resp.Header,
+	}, nil
+}
+
+// NDJSONDecoder reads newline-delimited JSON values from a stream
+type NDJSONDecoder struct {
+	reader *bufio.Reader
+}
+
+// NewNDJSONDecoder returns an *NDJSONDecoder reading from r
+func NewNDJSONDecoder(r io.Reader) *NDJSONDecoder {
+	return &NDJSONDecoder{
+		reader: bufio.NewReader(r),
+	}
+}
+
+// Decode unmarshals the next line into v; blank lines are skipped, and io.EOF is returned
+// once the stream is exhausted
+func (d *NDJSONDecoder) Decode(v interface{}) error {
+	for {
+		line, err := d.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return json.Unmarshal(line, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Event is a server-sent event
+type Event struct {
+	ID    string
+	Event string
+	Data  []byte
+	Retry time.Duration
+}
+
+// Unmarshal unmarshals the JSON data of the event into v
+func (e *Event) Unmarshal(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// EventDecoder reads server-sent events from a stream, as specified by the HTML living
+// standard; the id of the last event is retained across events
+type EventDecoder struct {
+	reader *bufio.Reader
+	lastID string
+}
+
+// NewEventDecoder returns an *EventDecoder reading from r
+func NewEventDecoder(r io.Reader) *EventDecoder {
+	return &EventDecoder{
+		reader: bufio.NewReader(r),
+	}
+}
+
+// LastEventID returns the id of the most recent event which set one, which may be sent as the
+// Last-Event-ID header when reconnecting
+func (d *EventDecoder) LastEventID() string {
+	return d.lastID
+}
+
+// Next returns the next event having data; io.EOF is returned once the stream is exhausted
+func (d *EventDecoder) Next() (*Event, error) {
+	evt := &Event{}
+	var data bytes.Buffer
+	hasData := false
+
+	for {
+		line, err := d.reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if hasData {
+				evt.ID = d.lastID
+				if evt.Event == "" {
+					evt.Event = "message"
+				}
+				evt.Data = bytes.TrimSuffix(data.Bytes(), []byte("\n"))
+				return evt, nil
+			}
+
+			evt.Event = ""
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			evt.Event = value
+		case "data":
+			hasData = true
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				d.lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 64); err == nil {
+				evt.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const traceparentHeader = "Traceparent"
+const tracestateHeader = "Tracestate"
+
+var (
+	tracer         Tracer
+	metrics        Metrics
+	telemetryMutex sync.RWMutex
+
+	// routeIDPattern matches the path segments which are replaced with {id} in route templates,
+	// i.e. uuids, integers, 0x-prefixed hex strings and hex digests
+	routeIDPattern = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9]+|0x[0-9a-fA-F]+|[0-9a-fA-F]{32,})$`)
+)
+
+// Tracer starts a span for each request sent by a client; it is implemented by adapting the
+// tracer of a tracing library, such as the OpenTelemetry adapter in the api/otel module, whose
+// span names and attribute keys follow the OpenTelemetry semantic conventions for HTTP clients
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer; the span context of the span is propagated to the API
+// using the W3C traceparent and tracestate headers
+type Span interface {
+	SpanContext() SpanContext
+	SetAttributes(attrs map[string]interface{})
+	RecordError(err error)
+	End()
+}
+
+// SpanContext identifies a span in accordance with the W3C trace context specification
+type SpanContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	TraceFlags byte
+	TraceState string
+}
+
+type spanContextKey struct{}
+
+// SetTracer sets the tracer used by every Client which has not been configured with its own;
+// a nil tracer disables tracing
+func SetTracer(t Tracer) {
+	telemetryMutex.Lock()
+	defer telemetryMutex.Unlock()
+	tracer = t
+}
+
+// SetMetrics sets the metrics recorder used by every Client which has not been configured with
+// its own; nil disables metrics
+func SetMetrics(m Metrics) {
+	telemetryMutex.Lock()
+	defer telemetryMutex.Unlock()
+	metrics = m
+}
+
+// ContextWithSpanContext returns a context carrying the given span context, which is propagated
+// to the API when no tracer is configured; it is typically parsed from an inbound request
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context carried by the given context, if any
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// ParseTraceparent parses the value of a W3C traceparent header
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	sc := SpanContext{}
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, fmt.Errorf("invalid traceparent: %s", traceparent)
+	}
+
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || version[0] == 0xff || (version[0] == 0 && len(parts) != 4) {
+		return sc, fmt.Errorf("invalid traceparent version: %s", parts[0])
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, fmt.Errorf("invalid traceparent flags: %s", parts[3])
+	}
+	sc.TraceFlags = flags[0]
+
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, fmt.Errorf("invalid traceparent trace id: %s", parts[1])
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, fmt.Errorf("invalid traceparent parent id: %s", parts[2])
+	}
+
+	if !sc.IsValid() {
+		return sc, errors.New("invalid traceparent; trace id and parent id must not be zero")
+	}
+	return sc, nil
+}
+
+// IsValid returns true if neither the trace id nor the span id is zero
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Sampled returns true if the sampled flag is set
+func (sc SpanContext) Sampled() bool {
+	return sc.TraceFlags&0x01 == 0x01
+}
+
+// Traceparent returns the value of the W3C traceparent header for the span context
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), sc.TraceFlags)
+}
+
+// requestTelemetry traces and measures a request, including all of its attempts
+type requestTelemetry struct {
+	ctx         context.Context
+	span        Span
+	metrics     Metrics
+	measurement *RequestMeasurement
+	started     time.Time
+}
+
+func (c *Client) tracer() Tracer {
+	if c.Tracer != nil {
+		return c.Tracer
+	}
+	telemetryMutex.RLock()
+	defer telemetryMutex.RUnlock()
+	return tracer
+}
+
+func (c *Client) metrics() Metrics {
+	if c.Metrics != nil {
+		return c.Metrics
+	}
+	telemetryMutex.RLock()
+	defer telemetryMutex.RUnlock()
+	return metrics
+}
+
+// serviceName returns the name of the service, or its host if the client was not configured
+// with a service name
+func (c *Client) serviceName() string {
+	if c.Service != "" {
+		return c.Service
+	}
+	return c.Host
+}
+
+// instrument starts the span of a request, if a tracer is configured, and sets the trace
+// context headers propagated to the API
+func (c *Client) instrument(ctx context.Context, method string, u *url.URL, headers map[string][]string) (context.Context, *requestTelemetry) {
+	route := routeTemplate(c.Path, u.Path)
+	t := &requestTelemetry{
+		ctx:     ctx,
+		metrics: c.metrics(),
+		measurement: &RequestMeasurement{
+			Service: c.serviceName(),
+			Method:  method,
+			Route:   route,
+		},
+		started: time.Now(),
+	}
+
+	if tr := c.tracer(); tr != nil {
+		redacted := map[string]bool{}
+		for _, field := range defaultRedactedFields {
+			redacted[field] = true
+		}
+
+		t.ctx, t.span = tr.Start(ctx, fmt.Sprintf("%s %s", method, route), map[string]interface{}{
+			"http.request.method": method,
+			"http.route":          route,
+			"peer.service":        t.measurement.Service,
+			"server.address":      u.Hostname(),
+			"url.full":            redactURL(u, redacted),
+		})
+	}
+
+	sc, ok := SpanContextFromContext(t.ctx)
+	if t.span != nil {
+		sc, ok = t.span.SpanContext(), t.span.SpanContext().IsValid()
+	}
+	if ok {
+		headers[traceparentHeader] = []string{sc.Traceparent()}
+		if sc.TraceState != "" {
+			headers[tracestateHeader] = []string{sc.TraceState}
+		}
+	}
+
+	return t.ctx, t
+}
+
+// retry records that the request is being resent
+func (t *requestTelemetry) retry() {
+	t.measurement.Retries++
+}
+
+// end ends the span of the request and records its measurement; for streams, the duration is
+// the time until the response headers were received
+func (t *requestTelemetry) end(resp *http.Response, err error) {
+	t.measurement.Duration = time.Since(t.started)
+	t.measurement.Err = err
+	if resp != nil {
+		t.measurement.Status = resp.StatusCode
+		if err == nil && resp.StatusCode >= 400 {
+			t.measurement.Err = NewAPIError(resp, nil)
+		}
+	}
+
+	if t.span != nil {
+		attrs := map[string]interface{}{}
+		if t.measurement.Status != 0 {
+			attrs["http.response.status_code"] = t.measurement.Status
+		}
+		if t.measurement.Retries > 0 {
+			attrs["http.request.resend_count"] = t.measurement.Retries
+		}
+		if t.measurement.Err != nil {
+			attrs["error.type"] = t.measurement.ErrorType()
+			t.span.RecordError(t.measurement.Err)
+		}
+		t.span.SetAttributes(attrs)
+		t.span.End()
+	}
+
+	if t.metrics != nil {
+		t.metrics.RecordRequest(t.ctx, t.measurement)
+	}
+}
+
+// routeTemplate returns the path of a request relative to the base path of the API, having
+// the segments which identify resources replaced with {id}
+func routeTemplate(basePath, path string) string {
+	path = strings.Trim(path, "/")
+	basePath = strings.Trim(basePath, "/")
+	if basePath != "" && (path == basePath || strings.HasPrefix(path, basePath+"/")) {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, basePath), "/")
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if routeIDPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
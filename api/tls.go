@@ -0,0 +1,74 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PublicKeyPin returns the pin of the public key of the given certificate, i.e. the base64
+// encoding of the SHA-256 digest of its subject public key info, as accepted by
+// WithPinnedPublicKeys
+func PublicKeyPin(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// cloneTLSClientConfig returns a copy of the given TLS configuration, or a new configuration
+// if it is nil, so options never modify a configuration shared with other clients
+func cloneTLSClientConfig(tlsClientConfig *tls.Config) *tls.Config {
+	if tlsClientConfig == nil {
+		return &tls.Config{}
+	}
+	return tlsClientConfig.Clone()
+}
+
+// parseCABundle returns a certificate pool containing each certificate in the PEM bundle
+func parseCABundle(pemCerts []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, errors.New("failed to parse CA bundle; no PEM-encoded certificates found")
+	}
+	return pool, nil
+}
+
+// verifyPinnedPublicKeys returns a function which verifies that a certificate presented by the
+// server has one of the given public key pins; when the chain has been verified, any certificate
+// in the chain may match, otherwise only the leaf certificate is considered
+func verifyPinnedPublicKeys(pins []string, next func([][]byte, [][]*x509.Certificate) error) func([][]byte, [][]*x509.Certificate) error {
+	pinned := map[string]bool{}
+	for _, pin := range pins {
+		pinned[strings.TrimPrefix(pin, "sha256/")] = true
+	}
+
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if next != nil {
+			if err := next(rawCerts, verifiedChains); err != nil {
+				return err
+			}
+		}
+
+		candidates := make([]*x509.Certificate, 0)
+		for _, chain := range verifiedChains {
+			candidates = append(candidates, chain...)
+		}
+		if len(candidates) == 0 && len(rawCerts) > 0 {
+			leaf, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate; %s", err.Error())
+			}
+			candidates = append(candidates, leaf)
+		}
+
+		for _, cert := range candidates {
+			if pinned[PublicKeyPin(cert)] {
+				return nil
+			}
+		}
+		return errors.New("server certificate does not match a pinned public key")
+	}
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"container/list"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultTransportDialTimeout = time.Second * 30
+const defaultTransportDialKeepAlive = time.Second * 30
+const defaultTransportIdleConnTimeout = time.Second * 90
+const defaultTransportMaxIdleConns = 100
+const defaultTransportMaxIdleConnsPerHost = 16
+const defaultTransportTLSHandshakeTimeout = time.Second * 10
+
+// maxPooledTransports bounds the number of pooled transports; TLS configurations are pooled by
+// identity, so a caller building a new configuration per request would otherwise grow the pool,
+// and the idle connections it holds, without bound
+const maxPooledTransports = 32
+
+// defaultTLSClientConfig is shared by clients which have not been configured with a
+// custom TLS configuration, so they also share a single pooled transport
+var defaultTLSClientConfig = &tls.Config{
+	InsecureSkipVerify: false,
+}
+
+var (
+	// transports indexes the elements of transportsLRU, which holds the *pooledTransport
+	// instances ordered from most to least recently used
+	transports      map[transportKey]*list.Element
+	transportsLRU   *list.List
+	transportsMutex sync.Mutex
+)
+
+// TransportConfig configures the pooled http.Transport used by an api.Client; transports
+// are shared by all clients having the same TLS configuration and equivalent transport
+// configurations
+type TransportConfig struct {
+	// DisableKeepAlives disables connection reuse, forcing a new connection for each request
+	DisableKeepAlives bool
+
+	// DisableHTTP2 prevents the transport from negotiating HTTP/2 over TLS
+	DisableHTTP2 bool
+
+	// IdleConnTimeout is the maximum amount of time an idle connection remains in the pool
+	IdleConnTimeout time.Duration
+
+	// MaxIdleConns is the maximum number of idle connections across all hosts
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections to keep per host
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the total number of connections per host; zero means no limit
+	MaxConnsPerHost int
+
+	// ProxyURL, when set, is the url of the http, https or socks5 proxy through which all
+	// requests are sent
+	ProxyURL string
+
+	// ProxyFromEnvironment sends requests through the proxy configured using the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables, unless ProxyURL is set
+	ProxyFromEnvironment bool
+}
+
+type transportKey struct {
+	tlsClientConfig *tls.Config
+	config          TransportConfig
+}
+
+type pooledTransport struct {
+	key       transportKey
+	transport *http.Transport
+}
+
+// DefaultTransportConfig returns the transport configuration used when none is configured on the client
+func DefaultTransportConfig() *TransportConfig {
+	return &TransportConfig{
+		IdleConnTimeout:     defaultTransportIdleConnTimeout,
+		MaxIdleConns:        defaultTransportMaxIdleConns,
+		MaxIdleConnsPerHost: defaultTransportMaxIdleConnsPerHost,
+	}
+}
+
+// cloneTransportConfig returns a copy of the given transport configuration, or the default
+// configuration if it is nil
+func cloneTransportConfig(config *TransportConfig) *TransportConfig {
+	if config == nil {
+		return DefaultTransportConfig()
+	}
+	clone := *config
+	return &clone
+}
+
+// CloseIdleConnections closes the idle connections held by all pooled transports
+func CloseIdleConnections() {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	if transportsLRU == nil {
+		return
+	}
+	for elem := transportsLRU.Front(); elem != nil; elem = elem.Next() {
+		elem.Value.(*pooledTransport).transport.CloseIdleConnections()
+	}
+}
+
+// httpClient returns the configured *http.Client, or an *http.Client which uses the pooled
+// transport for the TLS configuration of the client; either way, the transport is wrapped with the
+// middleware chain
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		client := *c.HTTPClient
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		client.Transport = c.chain(transport)
+		return &client
+	}
+
+	timeout := requestTimeout()
+	if c.Timeout > 0 {
+		timeout = c.Timeout
+	}
+
+	return &http.Client{
+		Transport: c.chain(c.transport(c.TLSClientConfig)),
+		Timeout:   timeout,
+	}
+}
+
+// streamingHTTPClient returns a copy of the *http.Client for a request whose body or response
+// is streamed, which is not bound by the default request timeout; the timeout of the client,
+// when set, or otherwise that of the configured HTTPClient still applies
+func (c *Client) streamingHTTPClient() *http.Client {
+	client := c.httpClient()
+	if c.Timeout > 0 || c.HTTPClient == nil {
+		client.Timeout = c.Timeout
+	}
+	return client
+}
+
+// transport resolves the pooled transport for the given TLS configuration and the
+// transport configuration of the client, initializing it if necessary; when the pool is full,
+// the least recently used transport is evicted and its idle connections are closed
+func (c *Client) transport(tlsClientConfig *tls.Config) *http.Transport {
+	if tlsClientConfig == nil {
+		tlsClientConfig = defaultTLSClientConfig
+	}
+
+	config := c.TransportConfig
+	if config == nil {
+		config = DefaultTransportConfig()
+	}
+
+	key := transportKey{
+		tlsClientConfig: tlsClientConfig,
+		config:          *config,
+	}
+
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	if transports == nil {
+		transports = map[transportKey]*list.Element{}
+		transportsLRU = list.New()
+	}
+
+	if elem, elemOk := transports[key]; elemOk {
+		transportsLRU.MoveToFront(elem)
+		return elem.Value.(*pooledTransport).transport
+	}
+
+	var proxy func(*http.Request) (*url.URL, error)
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			common.Log.Warningf("failed to parse proxy url; requests will fail; %s", err.Error())
+			proxy = func(*http.Request) (*url.URL, error) {
+				return nil, fmt.Errorf("invalid proxy url; %s", err.Error())
+			}
+		} else {
+			proxy = http.ProxyURL(proxyURL)
+		}
+	} else if config.ProxyFromEnvironment {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   defaultTransportDialTimeout,
+			KeepAlive: defaultTransportDialKeepAlive,
+		}).DialContext,
+		DisableKeepAlives:   config.DisableKeepAlives,
+		ForceAttemptHTTP2:   !config.DisableHTTP2,
+		IdleConnTimeout:     config.IdleConnTimeout,
+		MaxConnsPerHost:     config.MaxConnsPerHost,
+		MaxIdleConns:        config.MaxIdleConns,
+		MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		Proxy:               proxy,
+		TLSClientConfig:     tlsClientConfig,
+		TLSHandshakeTimeout: defaultTransportTLSHandshakeTimeout,
+	}
+
+	transports[key] = transportsLRU.PushFront(&pooledTransport{key: key, transport: transport})
+	for transportsLRU.Len() > maxPooledTransports {
+		evicted := transportsLRU.Remove(transportsLRU.Back()).(*pooledTransport)
+		delete(transports, evicted.key)
+		evicted.transport.CloseIdleConnections()
+	}
+
+	return transport
+}
@@ -1,9 +1,8 @@
 package vault
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -18,53 +17,56 @@ type Service struct {
 	api.Client
 }
 
-// InitVaultService convenience method to initialize an `vault.Service` instance
-func InitVaultService(token *string) *Service {
-	host := defaultVaultHost
-	if os.Getenv("VAULT_API_HOST") != "" {
-		host = os.Getenv("VAULT_API_HOST")
-	}
+// ConfigFromEnv resolves the configuration of the vault api from the VAULT_API_HOST,
+// VAULT_API_PATH and VAULT_API_SCHEME environment variables
+func ConfigFromEnv() *api.Config {
+	return api.ConfigFromEnv("VAULT", defaultVaultHost, defaultVaultPath, defaultVaultScheme)
+}
 
-	path := defaultVaultPath
-	if os.Getenv("VAULT_API_PATH") != "" {
-		path = os.Getenv("VAULT_API_PATH")
+// NewService initializes an `vault.Service` instance configured from the environment
+// and the given options
+func NewService(opts ...api.Option) (*Service, error) {
+	config := ConfigFromEnv()
+	err := config.Apply(opts...)
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := defaultVaultScheme
-	if os.Getenv("VAULT_API_SCHEME") != "" {
-		scheme = os.Getenv("VAULT_API_SCHEME")
-	}
+	return NewServiceWithConfig(config), nil
+}
 
-	return &Service{
-		api.Client{
-			Host:   host,
-			Path:   path,
-			Scheme: scheme,
-			Token:  token,
-		},
-	}
+// NewServiceWithConfig initializes an `vault.Service` instance using the given configuration
+func NewServiceWithConfig(config *api.Config) *Service {
+	return &Service{config.Client()}
+}
+
+// InitVaultService convenience method to initialize an `vault.Service` instance
+func InitVaultService(token *string) *Service {
+	config := ConfigFromEnv()
+	config.Token = token
+	return NewServiceWithConfig(config)
 }
 
 // CreateVault on behalf of the given API token
 func CreateVault(token string, params map[string]interface{}) (*Vault, error) {
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post("vaults", params)
-	if err != nil {
-		return nil, err
-	}
+	return CreateVaultWithContext(context.Background(), token, params)
+}
 
-	if status != 201 {
-		return nil, fmt.Errorf("failed to create vault; status: %v; %s", status, resp)
-	}
+// CreateVaultWithContext on behalf of the given API token
+func CreateVaultWithContext(ctx context.Context, token string, params map[string]interface{}) (*Vault, error) {
+	return InitVaultService(common.StringOrNil(token)).CreateVault(ctx, params)
+}
 
+// CreateVault on behalf of the given API token
+func (s *Service) CreateVault(ctx context.Context, params map[string]interface{}) (*Vault, error) {
 	vlt := &Vault{}
-	vltraw, err := json.Marshal(resp)
+	status, err := s.PostInto(ctx, "vaults", params, vlt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create vault; status: %v; %s", status, err.Error())
+		return nil, err
 	}
-	err = json.Unmarshal(vltraw, &vlt)
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to create vault; status: %v; %s", status, err.Error())
+	if status != 201 {
+		return nil, fmt.Errorf("failed to create vault; status: %v", status)
 	}
 
 	return vlt, nil
@@ -72,81 +74,97 @@ func CreateVault(token string, params map[string]interface{}) (*Vault, error) {
 
 // ListVaults retrieves a paginated list of vaults scoped to the given API token
 func ListVaults(token string, params map[string]interface{}) ([]*Vault, error) {
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Get("vaults", params)
+	return ListVaultsWithContext(context.Background(), token, params)
+}
+
+// ListVaultsWithContext retrieves a paginated list of vaults scoped to the given API token
+func ListVaultsWithContext(ctx context.Context, token string, params map[string]interface{}) ([]*Vault, error) {
+	return InitVaultService(common.StringOrNil(token)).ListVaults(ctx, params)
+}
+
+// ListVaults retrieves a paginated list of vaults scoped to the given API token
+func (s *Service) ListVaults(ctx context.Context, params map[string]interface{}) ([]*Vault, error) {
+	vaults := make([]*Vault, 0)
+	status, err := s.GetInto(ctx, "vaults", params, &vaults)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, resp)
-	}
-
-	vaults := make([]*Vault, 0)
-	for _, item := range resp.([]interface{}) {
-		vlt := &Vault{}
-		vltraw, err := json.Marshal(item)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, err.Error())
-		}
-		err = json.Unmarshal(vltraw, &vlt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, err.Error())
-		}
-		vaults = append(vaults, vlt)
+		return nil, fmt.Errorf("failed to fetch vaults; status: %v", status)
 	}
 
 	return vaults, nil
 }
 
+// ListVaultsPager returns an *api.Pager which walks all pages of the ListVaults results
+func ListVaultsPager(token string, params map[string]interface{}) *api.Pager {
+	return InitVaultService(common.StringOrNil(token)).ListVaultsPager(params)
+}
+
+// ListVaultsPager returns an *api.Pager which walks all pages of the ListVaults results
+func (s *Service) ListVaultsPager(params map[string]interface{}) *api.Pager {
+	return s.Pager("vaults", params)
+}
+
 // ListKeys retrieves a paginated list of vault keys
 func ListKeys(token, vaultID string, params map[string]interface{}) ([]*Key, error) {
+	return ListKeysWithContext(context.Background(), token, vaultID, params)
+}
+
+// ListKeysWithContext retrieves a paginated list of vault keys
+func ListKeysWithContext(ctx context.Context, token, vaultID string, params map[string]interface{}) ([]*Key, error) {
+	return InitVaultService(common.StringOrNil(token)).ListKeys(ctx, vaultID, params)
+}
+
+// ListKeys retrieves a paginated list of vault keys
+func (s *Service) ListKeys(ctx context.Context, vaultID string, params map[string]interface{}) ([]*Key, error) {
 	uri := fmt.Sprintf("vaults/%s/keys", vaultID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, params)
+	keys := make([]*Key, 0)
+	status, err := s.GetInto(ctx, uri, params, &keys)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to fetch keys; status: %v; %s", status, resp)
-	}
-
-	keys := make([]*Key, 0)
-	for _, item := range resp.([]interface{}) {
-		key := &Key{}
-		keyraw, err := json.Marshal(item)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list vault keys; status: %v; %s", status, err.Error())
-		}
-		err = json.Unmarshal(keyraw, &key)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list vault keys; status: %v; %s", status, err.Error())
-		}
-		keys = append(keys, key)
+		return nil, fmt.Errorf("failed to fetch keys; status: %v", status)
 	}
 
 	return keys, nil
 }
 
+// ListKeysPager returns an *api.Pager which walks all pages of the ListKeys results
+func ListKeysPager(token, vaultID string, params map[string]interface{}) *api.Pager {
+	return InitVaultService(common.StringOrNil(token)).ListKeysPager(vaultID, params)
+}
+
+// ListKeysPager returns an *api.Pager which walks all pages of the ListKeys results
+func (s *Service) ListKeysPager(vaultID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("vaults/%s/keys", vaultID)
+	return s.Pager(uri, params)
+}
+
 // CreateKey creates a new vault key
 func CreateKey(token, vaultID string, params map[string]interface{}) (*Key, error) {
+	return CreateKeyWithContext(context.Background(), token, vaultID, params)
+}
+
+// CreateKeyWithContext creates a new vault key
+func CreateKeyWithContext(ctx context.Context, token, vaultID string, params map[string]interface{}) (*Key, error) {
+	return InitVaultService(common.StringOrNil(token)).CreateKey(ctx, vaultID, params)
+}
+
+// CreateKey creates a new vault key
+func (s *Service) CreateKey(ctx context.Context, vaultID string, params map[string]interface{}) (*Key, error) {
 	uri := fmt.Sprintf("vaults/%s/keys", vaultID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, params)
+	key := &Key{}
+	status, err := s.PostInto(ctx, uri, params, key)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 201 {
-		return nil, fmt.Errorf("failed to create vault key; status: %v; %s", status, resp)
-	}
-
-	key := &Key{}
-	keyraw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create vault key; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(keyraw, &key)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create vault key; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to create vault key; status: %v", status)
 	}
 
 	return key, nil
@@ -154,25 +172,25 @@ func CreateKey(token, vaultID string, params map[string]interface{}) (*Key, erro
 
 // FetchKey fetches a key from the given vault
 func FetchKey(token, vaultID, keyID string) (*Key, error) {
-	uri := fmt.Sprintf("vaults/%s/keys/%s", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, map[string]interface{}{})
-	if err != nil {
-		return nil, err
-	}
+	return FetchKeyWithContext(context.Background(), token, vaultID, keyID)
+}
 
-	if status != 200 {
-		return nil, fmt.Errorf("failed to fetch key; status: %v; %s", status, resp)
-	}
+// FetchKeyWithContext fetches a key from the given vault
+func FetchKeyWithContext(ctx context.Context, token, vaultID, keyID string) (*Key, error) {
+	return InitVaultService(common.StringOrNil(token)).FetchKey(ctx, vaultID, keyID)
+}
 
+// FetchKey fetches a key from the given vault
+func (s *Service) FetchKey(ctx context.Context, vaultID, keyID string) (*Key, error) {
+	uri := fmt.Sprintf("vaults/%s/keys/%s", vaultID, keyID)
 	key := &Key{}
-	keyraw, err := json.Marshal(resp)
+	status, err := s.GetInto(ctx, uri, map[string]interface{}{}, key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch key; status: %v; %s", status, err.Error())
+		return nil, err
 	}
-	err = json.Unmarshal(keyraw, &key)
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch key; status: %v; %s", status, err.Error())
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch key; status: %v", status)
 	}
 
 	return key, nil
@@ -180,23 +198,25 @@ func FetchKey(token, vaultID, keyID string) (*Key, error) {
 
 // DeriveKey derives a key
 func DeriveKey(token, vaultID, keyID string, params map[string]interface{}) (*Key, error) {
+	return DeriveKeyWithContext(context.Background(), token, vaultID, keyID, params)
+}
+
+// DeriveKeyWithContext derives a key
+func DeriveKeyWithContext(ctx context.Context, token, vaultID, keyID string, params map[string]interface{}) (*Key, error) {
+	return InitVaultService(common.StringOrNil(token)).DeriveKey(ctx, vaultID, keyID, params)
+}
+
+// DeriveKey derives a key
+func (s *Service) DeriveKey(ctx context.Context, vaultID, keyID string, params map[string]interface{}) (*Key, error) {
 	uri := fmt.Sprintf("vaults/%s/keys/%s/derive", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, params)
+	key := &Key{}
+	status, err := s.PostInto(ctx, uri, params, key)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 201 {
-		return nil, fmt.Errorf("failed to derive vault key; status: %v; %s", status, resp)
-	}
-
-	// FIXME...
-	key := &Key{}
-	keyraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(keyraw, &key)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive key; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to derive vault key; status: %v", status)
 	}
 
 	return key, nil
@@ -204,8 +224,18 @@ func DeriveKey(token, vaultID, keyID string, params map[string]interface{}) (*Ke
 
 // DeleteKey deletes a key
 func DeleteKey(token, vaultID, keyID string) error {
+	return DeleteKeyWithContext(context.Background(), token, vaultID, keyID)
+}
+
+// DeleteKeyWithContext deletes a key
+func DeleteKeyWithContext(ctx context.Context, token, vaultID, keyID string) error {
+	return InitVaultService(common.StringOrNil(token)).DeleteKey(ctx, vaultID, keyID)
+}
+
+// DeleteKey deletes a key
+func (s *Service) DeleteKey(ctx context.Context, vaultID, keyID string) error {
 	uri := fmt.Sprintf("vaults/%s/keys/%s", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Delete(uri)
+	status, resp, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -219,28 +249,28 @@ func DeleteKey(token, vaultID, keyID string) error {
 
 // SignMessage signs a message with the given key
 func SignMessage(token, vaultID, keyID, msg string, opts map[string]interface{}) (*SignResponse, error) {
+	return SignMessageWithContext(context.Background(), token, vaultID, keyID, msg, opts)
+}
+
+// SignMessageWithContext signs a message with the given key
+func SignMessageWithContext(ctx context.Context, token, vaultID, keyID, msg string, opts map[string]interface{}) (*SignResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).SignMessage(ctx, vaultID, keyID, msg, opts)
+}
+
+// SignMessage signs a message with the given key
+func (s *Service) SignMessage(ctx context.Context, vaultID, keyID, msg string, opts map[string]interface{}) (*SignResponse, error) {
 	uri := fmt.Sprintf("vaults/%s/keys/%s/sign", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+	r := &SignResponse{}
+	status, err := s.PostInto(ctx, uri, map[string]interface{}{
 		"message": msg,
 		"options": opts,
-	})
+	}, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 201 {
-		return nil, fmt.Errorf("failed to sign message with key; status: %v; %s", status, resp)
-	}
-
-	r := &SignResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign message with key; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(raw, &r)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign message with key; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to sign message with key; status: %v", status)
 	}
 
 	return r, nil
@@ -248,30 +278,29 @@ func SignMessage(token, vaultID, keyID, msg string, opts map[string]interface{})
 
 // VerifySignature verifies a signature
 func VerifySignature(token, vaultID, keyID, msg, sig string, opts map[string]interface{}) (*VerifyResponse, error) {
+	return VerifySignatureWithContext(context.Background(), token, vaultID, keyID, msg, sig, opts)
+}
+
+// VerifySignatureWithContext verifies a signature
+func VerifySignatureWithContext(ctx context.Context, token, vaultID, keyID, msg, sig string, opts map[string]interface{}) (*VerifyResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).VerifySignature(ctx, vaultID, keyID, msg, sig, opts)
+}
+
+// VerifySignature verifies a signature
+func (s *Service) VerifySignature(ctx context.Context, vaultID, keyID, msg, sig string, opts map[string]interface{}) (*VerifyResponse, error) {
 	uri := fmt.Sprintf("vaults/%s/keys/%s/verify", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+	r := &VerifyResponse{}
+	status, err := s.PostInto(ctx, uri, map[string]interface{}{
 		"message":   msg,
 		"signature": sig,
 		"options":   opts,
-	})
+	}, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to verify message signature; status: %v; %s", status, resp)
-	}
-
-	r := &VerifyResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify message signature; status: %v; %s", status, err.Error())
-	}
-
-	err = json.Unmarshal(raw, &r)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify message signature; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to verify message signature; status: %v", status)
 	}
 
 	return r, nil
@@ -279,59 +308,67 @@ func VerifySignature(token, vaultID, keyID, msg, sig string, opts map[string]int
 
 // ListSecrets retrieves a paginated list of secrets in the vault
 func ListSecrets(token, vaultID string, params map[string]interface{}) ([]*Secret, error) {
+	return ListSecretsWithContext(context.Background(), token, vaultID, params)
+}
+
+// ListSecretsWithContext retrieves a paginated list of secrets in the vault
+func ListSecretsWithContext(ctx context.Context, token, vaultID string, params map[string]interface{}) ([]*Secret, error) {
+	return InitVaultService(common.StringOrNil(token)).ListSecrets(ctx, vaultID, params)
+}
+
+// ListSecrets retrieves a paginated list of secrets in the vault
+func (s *Service) ListSecrets(ctx context.Context, vaultID string, params map[string]interface{}) ([]*Secret, error) {
 	uri := fmt.Sprintf("vaults/%s/secrets", vaultID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, params)
+	secrets := make([]*Secret, 0)
+	status, err := s.GetInto(ctx, uri, params, &secrets)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, resp)
-	}
-
-	secrets := make([]*Secret, 0)
-	for _, item := range resp.([]interface{}) {
-		secret := &Secret{}
-		secretraw, err := json.Marshal(item)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, resp)
-		}
-		err = json.Unmarshal(secretraw, &secret)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, resp)
-		}
-		secrets = append(secrets, secret)
+		return nil, fmt.Errorf("failed to fetch secrets; status: %v", status)
 	}
 
 	return secrets, nil
 }
 
+// ListSecretsPager returns an *api.Pager which walks all pages of the ListSecrets results
+func ListSecretsPager(token, vaultID string, params map[string]interface{}) *api.Pager {
+	return InitVaultService(common.StringOrNil(token)).ListSecretsPager(vaultID, params)
+}
+
+// ListSecretsPager returns an *api.Pager which walks all pages of the ListSecrets results
+func (s *Service) ListSecretsPager(vaultID string, params map[string]interface{}) *api.Pager {
+	uri := fmt.Sprintf("vaults/%s/secrets", vaultID)
+	return s.Pager(uri, params)
+}
+
 // CreateSecret stores a new secret in the vault
 func CreateSecret(token, vaultID, value, name, description, secretType string) (*Secret, error) {
+	return CreateSecretWithContext(context.Background(), token, vaultID, value, name, description, secretType)
+}
+
+// CreateSecretWithContext stores a new secret in the vault
+func CreateSecretWithContext(ctx context.Context, token, vaultID, value, name, description, secretType string) (*Secret, error) {
+	return InitVaultService(common.StringOrNil(token)).CreateSecret(ctx, vaultID, value, name, description, secretType)
+}
+
+// CreateSecret stores a new secret in the vault
+func (s *Service) CreateSecret(ctx context.Context, vaultID, value, name, description, secretType string) (*Secret, error) {
 	uri := fmt.Sprintf("vaults/%s/secrets", vaultID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+	secret := &Secret{}
+	status, err := s.PostInto(ctx, uri, map[string]interface{}{
 		"name":        name,
 		"description": description,
 		"type":        secretType,
 		"value":       value,
-	})
+	}, secret)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 201 {
-		return nil, fmt.Errorf("failed to create secret; status: %v; %s", status, resp)
-	}
-
-	secret := &Secret{}
-	secretraw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create secret; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(secretraw, &secret)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create secret; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to create secret; status: %v", status)
 	}
 
 	return secret, nil
@@ -339,25 +376,25 @@ func CreateSecret(token, vaultID, value, name, description, secretType string) (
 
 // FetchSecret fetches a secret from the given vault
 func FetchSecret(token, vaultID, secretID string, params map[string]interface{}) (*Secret, error) {
-	uri := fmt.Sprintf("vaults/%s/secrets/%s", vaultID, secretID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, params)
-	if err != nil {
-		return nil, err
-	}
+	return FetchSecretWithContext(context.Background(), token, vaultID, secretID, params)
+}
 
-	if status != 200 {
-		return nil, fmt.Errorf("failed to fetch secret; status: %v; %s", status, resp)
-	}
+// FetchSecretWithContext fetches a secret from the given vault
+func FetchSecretWithContext(ctx context.Context, token, vaultID, secretID string, params map[string]interface{}) (*Secret, error) {
+	return InitVaultService(common.StringOrNil(token)).FetchSecret(ctx, vaultID, secretID, params)
+}
 
+// FetchSecret fetches a secret from the given vault
+func (s *Service) FetchSecret(ctx context.Context, vaultID, secretID string, params map[string]interface{}) (*Secret, error) {
+	uri := fmt.Sprintf("vaults/%s/secrets/%s", vaultID, secretID)
 	secret := &Secret{}
-	secretraw, err := json.Marshal(resp)
+	status, err := s.GetInto(ctx, uri, params, secret)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch secret; status: %v; %s", status, err.Error())
+		return nil, err
 	}
-	err = json.Unmarshal(secretraw, &secret)
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch secret; status: %v; %s", status, err.Error())
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch secret; status: %v", status)
 	}
 
 	return secret, nil
@@ -365,8 +402,18 @@ func FetchSecret(token, vaultID, secretID string, params map[string]interface{})
 
 // DeleteSecret deletes a secret from the vault
 func DeleteSecret(token, vaultID, secretID string) error {
+	return DeleteSecretWithContext(context.Background(), token, vaultID, secretID)
+}
+
+// DeleteSecretWithContext deletes a secret from the vault
+func DeleteSecretWithContext(ctx context.Context, token, vaultID, secretID string) error {
+	return InitVaultService(common.StringOrNil(token)).DeleteSecret(ctx, vaultID, secretID)
+}
+
+// DeleteSecret deletes a secret from the vault
+func (s *Service) DeleteSecret(ctx context.Context, vaultID, secretID string) error {
 	uri := fmt.Sprintf("vaults/%s/secrets/%s", vaultID, secretID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Delete(uri)
+	status, resp, err := s.DeleteWithContext(ctx, uri)
 	if err != nil {
 		return err
 	}
@@ -380,26 +427,27 @@ func DeleteSecret(token, vaultID, secretID string) error {
 
 // Encrypt encrypts provided data with a key from the vault and a randomly generated nonce
 func Encrypt(token, vaultID, keyID, data string) (*EncryptDecryptRequestResponse, error) {
+	return EncryptWithContext(context.Background(), token, vaultID, keyID, data)
+}
+
+// EncryptWithContext encrypts provided data with a key from the vault and a randomly generated nonce
+func EncryptWithContext(ctx context.Context, token, vaultID, keyID, data string) (*EncryptDecryptRequestResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).Encrypt(ctx, vaultID, keyID, data)
+}
+
+// Encrypt encrypts provided data with a key from the vault and a randomly generated nonce
+func (s *Service) Encrypt(ctx context.Context, vaultID, keyID, data string) (*EncryptDecryptRequestResponse, error) {
 	uri := fmt.Sprintf("vaults/%s/keys/%s/encrypt", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+	r := &EncryptDecryptRequestResponse{}
+	status, err := s.PostInto(ctx, uri, map[string]interface{}{
 		"data": data,
-	})
+	}, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to encrypt payload; status: %v; %s", status, resp)
-	}
-
-	r := &EncryptDecryptRequestResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt payload; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(raw, &r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt payload; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to encrypt payload; status: %v", status)
 	}
 
 	return r, nil
@@ -407,27 +455,28 @@ func Encrypt(token, vaultID, keyID, data string) (*EncryptDecryptRequestResponse
 
 // EncryptWithNonce encrypts provided data with a key from the vault and provided nonce
 func EncryptWithNonce(token, vaultID, keyID, data, nonce string) (*EncryptDecryptRequestResponse, error) {
+	return EncryptWithNonceWithContext(context.Background(), token, vaultID, keyID, data, nonce)
+}
+
+// EncryptWithNonceWithContext encrypts provided data with a key from the vault and provided nonce
+func EncryptWithNonceWithContext(ctx context.Context, token, vaultID, keyID, data, nonce string) (*EncryptDecryptRequestResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).EncryptWithNonce(ctx, vaultID, keyID, data, nonce)
+}
+
+// EncryptWithNonce encrypts provided data with a key from the vault and provided nonce
+func (s *Service) EncryptWithNonce(ctx context.Context, vaultID, keyID, data, nonce string) (*EncryptDecryptRequestResponse, error) {
 	uri := fmt.Sprintf("vaults/%s/keys/%s/encrypt", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+	r := &EncryptDecryptRequestResponse{}
+	status, err := s.PostInto(ctx, uri, map[string]interface{}{
 		"data":  data,
 		"nonce": nonce,
-	})
+	}, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to encrypt payload; status: %v; %s", status, resp)
-	}
-
-	r := &EncryptDecryptRequestResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt payload; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(raw, &r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt payload; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to encrypt payload; status: %v", status)
 	}
 
 	return r, nil
@@ -435,24 +484,25 @@ func EncryptWithNonce(token, vaultID, keyID, data, nonce string) (*EncryptDecryp
 
 // Decrypt decrypts provided encrypted data with a key from the vault
 func Decrypt(token, vaultID, keyID string, params map[string]interface{}) (*EncryptDecryptRequestResponse, error) {
+	return DecryptWithContext(context.Background(), token, vaultID, keyID, params)
+}
+
+// DecryptWithContext decrypts provided encrypted data with a key from the vault
+func DecryptWithContext(ctx context.Context, token, vaultID, keyID string, params map[string]interface{}) (*EncryptDecryptRequestResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).Decrypt(ctx, vaultID, keyID, params)
+}
+
+// Decrypt decrypts provided encrypted data with a key from the vault
+func (s *Service) Decrypt(ctx context.Context, vaultID, keyID string, params map[string]interface{}) (*EncryptDecryptRequestResponse, error) {
 	uri := fmt.Sprintf("vaults/%s/keys/%s/decrypt", vaultID, keyID)
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, params)
+	r := &EncryptDecryptRequestResponse{}
+	status, err := s.PostInto(ctx, uri, params, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to decrypt payload; status: %v; %s", status, resp)
-	}
-
-	r := &EncryptDecryptRequestResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt payload; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(raw, &r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt payload; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to decrypt payload; status: %v", status)
 	}
 
 	return r, nil
@@ -460,8 +510,18 @@ func Decrypt(token, vaultID, keyID string, params map[string]interface{}) (*Encr
 
 // Seal seals the vault to disable decryption of vault, key and secret material
 func Seal(token string, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
+	return SealWithContext(context.Background(), token, params)
+}
+
+// SealWithContext seals the vault to disable decryption of vault, key and secret material
+func SealWithContext(ctx context.Context, token string, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).Seal(ctx, params)
+}
+
+// Seal seals the vault to disable decryption of vault, key and secret material
+func (s *Service) Seal(ctx context.Context, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
 	uri := fmt.Sprintf("seal")
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, params)
+	status, resp, err := s.PostWithContext(ctx, uri, params)
 	if err != nil {
 		return nil, err
 	}
@@ -475,7 +535,17 @@ func Seal(token string, params map[string]interface{}) (*SealUnsealRequestRespon
 
 // Unseal unseals the vault to enable decryption of vault, key and secret material
 func Unseal(token *string, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
-	status, resp, err := InitVaultService(token).Post("unseal", params)
+	return UnsealWithContext(context.Background(), token, params)
+}
+
+// UnsealWithContext unseals the vault to enable decryption of vault, key and secret material
+func UnsealWithContext(ctx context.Context, token *string, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
+	return InitVaultService(token).Unseal(ctx, params)
+}
+
+// Unseal unseals the vault to enable decryption of vault, key and secret material
+func (s *Service) Unseal(ctx context.Context, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
+	status, resp, err := s.PostWithContext(ctx, "unseal", params)
 	if err != nil {
 		return nil, err
 	}
@@ -489,32 +559,45 @@ func Unseal(token *string, params map[string]interface{}) (*SealUnsealRequestRes
 
 // GenerateSeal returns a valid unsealing key used to encrypt vault master keys
 func GenerateSeal(token string, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
+	return GenerateSealWithContext(context.Background(), token, params)
+}
+
+// GenerateSealWithContext returns a valid unsealing key used to encrypt vault master keys
+func GenerateSealWithContext(ctx context.Context, token string, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).GenerateSeal(ctx, params)
+}
+
+// GenerateSeal returns a valid unsealing key used to encrypt vault master keys
+func (s *Service) GenerateSeal(ctx context.Context, params map[string]interface{}) (*SealUnsealRequestResponse, error) {
 	uri := fmt.Sprintf("unsealerkey")
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, params)
+	r := &SealUnsealRequestResponse{}
+	status, err := s.PostInto(ctx, uri, params, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 201 {
-		return nil, fmt.Errorf("failed to generate vault seal/unseal key; status: %v; %s", status, resp)
+		return nil, fmt.Errorf("failed to generate vault seal/unseal key; status: %v", status)
 	}
 
-	r := &SealUnsealRequestResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate vault seal/unseal key; status: %v; %s", status, err.Error())
-	}
-	err = json.Unmarshal(raw, &r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate vault seal/unseal key; status: %v; %s", status, err.Error())
-	}
 	return r, nil
 }
 
 // AggregateSignatures aggregates BLS signatures into a single BLS signature
 func AggregateSignatures(token *string, params map[string]interface{}) (*BLSAggregateRequestResponse, error) {
+	return AggregateSignaturesWithContext(context.Background(), token, params)
+}
+
+// AggregateSignaturesWithContext aggregates BLS signatures into a single BLS signature
+func AggregateSignaturesWithContext(ctx context.Context, token *string, params map[string]interface{}) (*BLSAggregateRequestResponse, error) {
+	return InitVaultService(token).AggregateSignatures(ctx, params)
+}
+
+// AggregateSignatures aggregates BLS signatures into a single BLS signature
+func (s *Service) AggregateSignatures(ctx context.Context, params map[string]interface{}) (*BLSAggregateRequestResponse, error) {
 	uri := fmt.Sprintf("bls/aggregate")
-	status, resp, err := InitVaultService(token).Post(uri, params)
+	response := &BLSAggregateRequestResponse{}
+	status, err := s.PostInto(ctx, uri, params, response)
 
 	if err != nil {
 		return nil, err
@@ -524,20 +607,24 @@ func AggregateSignatures(token *string, params map[string]interface{}) (*BLSAggr
 		return nil, fmt.Errorf("failed to aggregate bls signatures. status: %v", status)
 	}
 
-	response := &BLSAggregateRequestResponse{}
-	responseRaw, _ := json.Marshal(resp)
-	err = json.Unmarshal(responseRaw, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get aggregate response. status: %v; %s", status, err.Error())
-	}
-
 	return response, nil
 }
 
 // VerifyAggregateSignatures verifies a bls signature
 func VerifyAggregateSignatures(token *string, params map[string]interface{}) (*VerifyResponse, error) {
+	return VerifyAggregateSignaturesWithContext(context.Background(), token, params)
+}
+
+// VerifyAggregateSignaturesWithContext verifies a bls signature
+func VerifyAggregateSignaturesWithContext(ctx context.Context, token *string, params map[string]interface{}) (*VerifyResponse, error) {
+	return InitVaultService(token).VerifyAggregateSignatures(ctx, params)
+}
+
+// VerifyAggregateSignatures verifies a bls signature
+func (s *Service) VerifyAggregateSignatures(ctx context.Context, params map[string]interface{}) (*VerifyResponse, error) {
 	uri := fmt.Sprintf("bls/verify")
-	status, resp, err := InitVaultService(token).Post(uri, params)
+	response := &VerifyResponse{}
+	status, err := s.PostInto(ctx, uri, params, response)
 
 	if err != nil {
 		return nil, err
@@ -547,44 +634,36 @@ func VerifyAggregateSignatures(token *string, params map[string]interface{}) (*V
 		return nil, fmt.Errorf("failed to aggregate bls signatures. status: %v", status)
 	}
 
-	response := &VerifyResponse{}
-	responseRaw, _ := json.Marshal(resp)
-	err = json.Unmarshal(responseRaw, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get aggregate signature verification response. status: %v; %s", status, err.Error())
-	}
-
 	return response, nil
 }
 
 // VerifyDetachedSignature verifies a signature generated by a key external to vault
 func VerifyDetachedSignature(token, spec, msg, sig, publicKey string, opts map[string]interface{}) (*VerifyResponse, error) {
+	return VerifyDetachedSignatureWithContext(context.Background(), token, spec, msg, sig, publicKey, opts)
+}
+
+// VerifyDetachedSignatureWithContext verifies a signature generated by a key external to vault
+func VerifyDetachedSignatureWithContext(ctx context.Context, token, spec, msg, sig, publicKey string, opts map[string]interface{}) (*VerifyResponse, error) {
+	return InitVaultService(common.StringOrNil(token)).VerifyDetachedSignature(ctx, spec, msg, sig, publicKey, opts)
+}
+
+// VerifyDetachedSignature verifies a signature generated by a key external to vault
+func (s *Service) VerifyDetachedSignature(ctx context.Context, spec, msg, sig, publicKey string, opts map[string]interface{}) (*VerifyResponse, error) {
 	uri := fmt.Sprintf("verify")
-	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+	r := &VerifyResponse{}
+	status, err := s.PostInto(ctx, uri, map[string]interface{}{
 		"spec":       spec,
 		"public_key": publicKey,
 		"message":    msg,
 		"signature":  sig,
 		"options":    opts,
-	})
+	}, r)
 	if err != nil {
 		return nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to verify message signature; status: %v; %s", status, resp)
-	}
-
-	r := &VerifyResponse{}
-	raw, err := json.Marshal(resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify message signature; status: %v; %s", status, err.Error())
-	}
-
-	err = json.Unmarshal(raw, &r)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify message signature; status: %v; %s", status, err.Error())
+		return nil, fmt.Errorf("failed to verify message signature; status: %v", status)
 	}
 
 	return r, nil
@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/provideplatform/provide-go/api/ident"
+)
+
+// KeyResolver resolves the public key which verifies a JWT having the given key id
+type KeyResolver interface {
+	ResolveKey(ctx context.Context, kid string) (interface{}, error)
+}
+
+// KeyResolverFunc adapts an ordinary function to the KeyResolver interface
+type KeyResolverFunc func(ctx context.Context, kid string) (interface{}, error)
+
+// ResolveKey calls f(ctx, kid)
+func (f KeyResolverFunc) ResolveKey(ctx context.Context, kid string) (interface{}, error) {
+	return f(ctx, kid)
+}
+
+// IdentKeyResolver resolves the keys of JWT-signed webhooks from the well-known JWKs of ident;
+// the keys are cached, and refreshed periodically or when an unknown key id is encountered
+type IdentKeyResolver = ident.KeySet
+
+// NewIdentKeyResolver returns an *IdentKeyResolver using the given ident service, or the
+// service configured in the environment if it is nil
+func NewIdentKeyResolver(service *ident.Service) *IdentKeyResolver {
+	return ident.NewKeySet(service)
+}
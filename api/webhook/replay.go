@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// ReplayCache records the ids of verified webhooks so a webhook cannot be delivered twice;
+// implementations backed by a shared store protect receivers running on multiple hosts
+type ReplayCache interface {
+	// Seen returns true if the id has been recorded and has not expired, and otherwise
+	// records it until the given expiry
+	Seen(id string, expiry time.Time) bool
+}
+
+// MemoryReplayCache is an in-memory ReplayCache
+type MemoryReplayCache struct {
+	ids    map[string]time.Time
+	pruned time.Time
+	mutex  sync.Mutex
+}
+
+// NewMemoryReplayCache returns an empty *MemoryReplayCache
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return &MemoryReplayCache{
+		ids: map[string]time.Time{},
+	}
+}
+
+// Seen returns true if the id has been recorded and has not expired, and otherwise records it
+// until the given expiry; expired ids are pruned at most once a minute
+func (c *MemoryReplayCache) Seen(id string, expiry time.Time) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.pruned) > time.Minute {
+		for seen, exp := range c.ids {
+			if now.After(exp) {
+				delete(c.ids, seen)
+			}
+		}
+		c.pruned = now
+	}
+
+	if exp, ok := c.ids[id]; ok && !now.After(exp) {
+		return true
+	}
+	c.ids[id] = expiry
+	return false
+}
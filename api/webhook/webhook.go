@@ -0,0 +1,324 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/provideplatform/provide-go/api/baseline"
+	"github.com/provideplatform/provide-go/api/nchain"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// SignatureHeader is the header carrying the signature of an HMAC-signed webhook, having the
+// form t=<unix timestamp>,v1=<hex-encoded HMAC-SHA256 of "<timestamp>.<body>">
+const SignatureHeader = "X-Provide-Signature"
+
+// IDHeader is the header carrying the unique id of a webhook delivery; it is not covered by
+// the signature, so replays are detected using the signature or the jti claim instead
+const IDHeader = "X-Provide-Webhook-Id"
+
+// BodyDigestClaim is the required claim of a JWT-signed webhook which must be the unpadded
+// base64url-encoded SHA-256 digest of the body
+const BodyDigestClaim = "body_sha256"
+
+const defaultTolerance = time.Minute * 5
+const defaultMaxBodySize = 4 << 20
+
+var (
+	// ErrMissingSignature is returned when a webhook has no signature
+	ErrMissingSignature = errors.New("webhook signature missing")
+
+	// ErrInvalidSignature is returned when the signature of a webhook does not verify
+	ErrInvalidSignature = errors.New("webhook signature invalid")
+
+	// ErrTimestampOutOfTolerance is returned when a webhook was signed too long ago, or too
+	// far in the future
+	ErrTimestampOutOfTolerance = errors.New("webhook timestamp outside tolerance")
+
+	// ErrReplayed is returned when a webhook having the same signature, or a JWT having the
+	// same jti claim, has already been verified
+	ErrReplayed = errors.New("webhook replayed")
+)
+
+// Verifier verifies the authenticity of the webhooks delivered by Provide services, which are
+// signed either using an HMAC shared secret or as a JWT signed by an ident key
+type Verifier struct {
+	secret    []byte
+	keys      KeyResolver
+	tolerance time.Duration
+	replay    ReplayCache
+	audience  string
+	issuer    string
+	now       func() time.Time
+}
+
+// Option configures a Verifier
+type Option func(*Verifier)
+
+// WithTolerance sets the maximum age of a webhook, and the maximum skew between the clocks of
+// the sender and the receiver; the default is five minutes
+func WithTolerance(tolerance time.Duration) Option {
+	return func(v *Verifier) {
+		v.tolerance = tolerance
+	}
+}
+
+// WithReplayCache sets the cache of the ids of verified webhooks; by default, ids are held in
+// memory, which does not protect a receiver running on multiple hosts
+func WithReplayCache(cache ReplayCache) Option {
+	return func(v *Verifier) {
+		v.replay = cache
+	}
+}
+
+// WithIssuer requires JWT-signed webhooks to have the given iss claim
+func WithIssuer(issuer string) Option {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// NewHMACVerifier returns a *Verifier of webhooks signed using the given shared secret
+func NewHMACVerifier(secret []byte, opts ...Option) *Verifier {
+	return newVerifier(&Verifier{secret: secret}, opts)
+}
+
+// NewJWTVerifier returns a *Verifier of webhooks carrying a bearer JWT signed by one of the
+// keys resolved by the given resolver, typically an *IdentKeyResolver; the JWT must have the
+// given aud claim, so that other tokens signed by the same keys are not accepted as webhooks
+func NewJWTVerifier(keys KeyResolver, audience string, opts ...Option) *Verifier {
+	return newVerifier(&Verifier{keys: keys, audience: audience}, opts)
+}
+
+func newVerifier(v *Verifier, opts []Option) *Verifier {
+	v.tolerance = defaultTolerance
+	v.now = time.Now
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.replay == nil {
+		v.replay = NewMemoryReplayCache()
+	}
+	return v
+}
+
+// Verify reads the body of the given webhook request and verifies its signature, timestamp
+// and uniqueness; the verified body is returned, and also replaces the request body so it can
+// be read again
+func (v *Verifier) Verify(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, ErrMissingSignature
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, defaultMaxBodySize))
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook body; %s", err.Error())
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	err = v.VerifyPayload(r.Context(), r.Header, body)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// VerifyPayload verifies the signature, timestamp and uniqueness of a webhook having the given
+// headers and body
+func (v *Verifier) VerifyPayload(ctx context.Context, header http.Header, body []byte) error {
+	if v.keys != nil {
+		return v.verifyJWT(ctx, header, body)
+	}
+	return v.verifyHMAC(header, body)
+}
+
+// VerifyTransaction verifies the given webhook request and decodes its body as an
+// nchain transaction
+func (v *Verifier) VerifyTransaction(r *http.Request) (*nchain.Transaction, error) {
+	body, err := v.Verify(r)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTransaction(body)
+}
+
+// VerifyMessage verifies the given webhook request and decodes its body as a baseline message
+func (v *Verifier) VerifyMessage(r *http.Request) (*baseline.Message, error) {
+	body, err := v.Verify(r)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMessage(body)
+}
+
+// Middleware returns an http.Handler which responds 401 to webhooks which fail verification,
+// and otherwise calls next with the verified request
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := v.Verify(r); err != nil {
+			common.Log.Debugf("rejected webhook: %s %s; %s", r.Method, r.URL.Path, err.Error())
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// DecodeTransaction decodes the body of a webhook as an nchain transaction
+func DecodeTransaction(body []byte) (*nchain.Transaction, error) {
+	tx := &nchain.Transaction{}
+	if err := json.Unmarshal(body, tx); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction webhook; %s", err.Error())
+	}
+	return tx, nil
+}
+
+// DecodeMessage decodes the body of a webhook as a baseline message
+func DecodeMessage(body []byte) (*baseline.Message, error) {
+	msg := &baseline.Message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode baseline message webhook; %s", err.Error())
+	}
+	return msg, nil
+}
+
+// SignHMAC returns the value of the SignatureHeader of a webhook having the given body, signed
+// at the given time using the given shared secret
+func SignHMAC(secret, body []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(hmacSHA256(secret, timestamp, body)))
+}
+
+func hmacSHA256(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func (v *Verifier) verifyHMAC(header http.Header, body []byte) error {
+	signature := header.Get(SignatureHeader)
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	var timestamp string
+	signatures := make([][]byte, 0)
+	for _, field := range strings.Split(signature, ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			if sig, err := hex.DecodeString(kv[1]); err == nil {
+				signatures = append(signatures, sig)
+			}
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := hmacSHA256(v.secret, timestamp, body)
+	verified := false
+	for _, sig := range signatures {
+		if hmac.Equal(sig, expected) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if err := v.checkTimestamp(signedAt); err != nil {
+		return err
+	}
+
+	return v.checkReplay(hex.EncodeToString(expected), signedAt)
+}
+
+func (v *Verifier) verifyJWT(ctx context.Context, header http.Header, body []byte) error {
+	authorization := header.Get("Authorization")
+	if !strings.HasPrefix(strings.ToLower(authorization), "bearer ") {
+		return ErrMissingSignature
+	}
+
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512"}}
+	token, err := parser.ParseWithClaims(strings.TrimSpace(authorization[7:]), claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.ResolveKey(ctx, kid)
+	})
+	if err != nil {
+		return fmt.Errorf("%w; %s", ErrInvalidSignature, err.Error())
+	}
+
+	if v.audience == "" || !claims.VerifyAudience(v.audience, true) {
+		return fmt.Errorf("%w; invalid audience", ErrInvalidSignature)
+	}
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return fmt.Errorf("%w; invalid issuer", ErrInvalidSignature)
+	}
+
+	digest, ok := claims[BodyDigestClaim].(string)
+	if !ok {
+		return fmt.Errorf("%w; %s claim required", ErrInvalidSignature, BodyDigestClaim)
+	}
+	actual := sha256.Sum256(body)
+	if !hmac.Equal([]byte(digest), []byte(base64.RawURLEncoding.EncodeToString(actual[:]))) {
+		return fmt.Errorf("%w; body digest mismatch", ErrInvalidSignature)
+	}
+
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return fmt.Errorf("%w; iat claim required", ErrInvalidSignature)
+	}
+	signedAt := time.Unix(int64(iat), 0)
+	if err := v.checkTimestamp(signedAt); err != nil {
+		return err
+	}
+
+	id := token.Signature
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		id = "jti:" + jti
+	}
+	return v.checkReplay(id, signedAt)
+}
+
+func (v *Verifier) checkTimestamp(signedAt time.Time) error {
+	skew := v.now().Sub(signedAt)
+	if skew > v.tolerance || skew < -v.tolerance {
+		return ErrTimestampOutOfTolerance
+	}
+	return nil
+}
+
+// checkReplay records the id of a verified webhook until it falls outside the tolerance
+func (v *Verifier) checkReplay(id string, signedAt time.Time) error {
+	if v.replay.Seen(id, signedAt.Add(v.tolerance)) {
+		return ErrReplayed
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/provideplatform/provide-go/api/fake"
+	"github.com/provideplatform/provide-go/api/ident"
+)
+
+func webhookRequest(body string, header map[string]string) *http.Request {
+	r := httptest.NewRequest("POST", "/webhooks/transactions", strings.NewReader(body))
+	for name, val := range header {
+		r.Header.Set(name, val)
+	}
+	return r
+}
+
+func TestHMACVerifier(t *testing.T) {
+	secret := []byte("s3cr3t")
+	body := `{"id":"9d8e7c4a-1b2c-4d5e-8f90-a1b2c3d4e5f6","hash":"0xabc","status":"success"}`
+	verifier := NewHMACVerifier(secret)
+
+	signature := SignHMAC(secret, []byte(body), time.Now())
+	tx, err := verifier.VerifyTransaction(webhookRequest(body, map[string]string{SignatureHeader: signature, IDHeader: "delivery-1"}))
+	if err != nil {
+		t.Fatalf("failed to verify webhook; %s", err.Error())
+	}
+	if tx.Hash == nil || *tx.Hash != "0xabc" {
+		t.Errorf("expected decoded transaction; got %+v", tx)
+	}
+
+	_, err = verifier.Verify(webhookRequest(body, map[string]string{SignatureHeader: signature, IDHeader: "delivery-2"}))
+	if !errors.Is(err, ErrReplayed) {
+		t.Errorf("expected replay with a changed id header to be rejected; got %v", err)
+	}
+
+	for _, tc := range []struct {
+		body      string
+		signature string
+		err       error
+	}{
+		{body, signature, ErrReplayed},
+		{body + " ", SignHMAC(secret, []byte(body), time.Now()), ErrInvalidSignature},
+		{body, SignHMAC([]byte("other"), []byte(body), time.Now()), ErrInvalidSignature},
+		{body, SignHMAC(secret, []byte(body), time.Now().Add(-time.Hour)), ErrTimestampOutOfTolerance},
+		{body, "", ErrMissingSignature},
+	} {
+		_, err := verifier.Verify(webhookRequest(tc.body, map[string]string{SignatureHeader: tc.signature}))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("expected %v; got %v", tc.err, err)
+		}
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"message_id":"1","type":"general_consistency"}`
+	digest := sha256.Sum256([]byte(body))
+	sign := func(claims jwt.MapClaims) string {
+		claims["exp"] = time.Now().Add(time.Minute).Unix()
+		claims["aud"] = "https://example.com/webhooks"
+		claims[BodyDigestClaim] = base64.RawURLEncoding.EncodeToString(digest[:])
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	signed := sign(jwt.MapClaims{"iat": time.Now().Unix(), "jti": "delivery-1"})
+
+	keys := KeyResolverFunc(func(ctx context.Context, kid string) (interface{}, error) {
+		if kid != "k1" {
+			return nil, errors.New("unknown kid")
+		}
+		return &key.PublicKey, nil
+	})
+
+	_, err = NewJWTVerifier(keys, "https://example.com/other").Verify(webhookRequest(body, map[string]string{"Authorization": "bearer " + signed}))
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected webhook for another audience to be rejected; got %v", err)
+	}
+
+	verifier := NewJWTVerifier(keys, "https://example.com/webhooks")
+
+	_, err = verifier.Verify(webhookRequest(`{"message_id":"2"}`, map[string]string{"Authorization": "bearer " + signed}))
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected tampered body to be rejected; got %v", err)
+	}
+
+	msg, err := verifier.VerifyMessage(webhookRequest(body, map[string]string{"Authorization": "bearer " + signed}))
+	if err != nil {
+		t.Fatalf("failed to verify webhook; %s", err.Error())
+	}
+	if msg.Type == nil || *msg.Type != "general_consistency" {
+		t.Errorf("expected decoded message; got %+v", msg)
+	}
+
+	resigned := sign(jwt.MapClaims{"iat": time.Now().Add(-time.Second).Unix(), "jti": "delivery-1"})
+	_, err = verifier.Verify(webhookRequest(body, map[string]string{"Authorization": "bearer " + resigned}))
+	if !errors.Is(err, ErrReplayed) {
+		t.Errorf("expected redelivery having the same jti to be rejected; got %v", err)
+	}
+
+	unidentified := sign(jwt.MapClaims{"iat": time.Now().Unix()})
+	_, err = verifier.Verify(webhookRequest(body, map[string]string{"Authorization": "bearer " + unidentified, IDHeader: "delivery-2"}))
+	if err != nil {
+		t.Fatalf("failed to verify webhook without a jti claim; %s", err.Error())
+	}
+	_, err = verifier.Verify(webhookRequest(body, map[string]string{"Authorization": "bearer " + unidentified, IDHeader: "delivery-3"}))
+	if !errors.Is(err, ErrReplayed) {
+		t.Errorf("expected replay with a changed id header to be rejected; got %v", err)
+	}
+
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	resolver := NewIdentKeyResolver(ident.NewServiceWithConfig(srv.Config("ident", nil)))
+	verifier = NewJWTVerifier(resolver, "https://example.com/webhooks")
+	_, err = verifier.Verify(webhookRequest(body, map[string]string{"Authorization": "bearer " + srv.Token()}))
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected an ident access token without a body digest to be rejected; got %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	verifier.Middleware(http.NotFoundHandler()).ServeHTTP(rec, webhookRequest(body, map[string]string{"Authorization": "bearer " + srv.Token()}))
+	if rec.Code != http.StatusUnauthorized || strings.TrimSpace(rec.Body.String()) != http.StatusText(http.StatusUnauthorized) {
+		t.Errorf("expected a generic 401 response; got %d: %s", rec.Code, rec.Body.String())
+	}
+}
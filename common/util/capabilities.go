@@ -10,6 +10,9 @@ import (
 
 const maxManifestRetryCount = 10
 
+// capabilitiesManifestCache caches the capabilities manifest, which is revalidated using its ETag
+var capabilitiesManifestCache = api.NewLRUCache(1)
+
 // ResolveCapabilitiesManifest attempts to resolve the capabilities manifest from S3
 func ResolveCapabilitiesManifest() (map[string]interface{}, error) {
 	i := 0
@@ -19,6 +22,7 @@ func ResolveCapabilitiesManifest() (map[string]interface{}, error) {
 			Host:   "s3.amazonaws.com",
 			Scheme: "https",
 			Path:   "static.provide.services/capabilities",
+			Cache:  capabilitiesManifestCache,
 		}
 
 		_, resp, err := client.Get("provide-capabilities-manifest.json", map[string]interface{}{})
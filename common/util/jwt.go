@@ -1,8 +1,12 @@
 package util
 
 import (
+	"context"
+	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -90,6 +94,59 @@ type JWTKeypair struct {
 	VaultKey     *vault.Key
 }
 
+// SignJWT signs the given claims using RS256 with the private key of the keypair or, when the
+// keypair is vault-backed, its vault key; the kid header is set to the keypair fingerprint
+func (j *JWTKeypair) SignJWT(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = j.Fingerprint
+
+	if j.PrivateKey != nil {
+		return token.SignedString(j.PrivateKey)
+	}
+
+	if j.VaultKey == nil || j.VaultKey.VaultID == nil {
+		return "", fmt.Errorf("failed to sign JWT; no signing key configured for keypair: %s", j.Fingerprint)
+	}
+
+	signingString, err := token.SigningString()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT; %s", err.Error())
+	}
+
+	hash := crypto.SHA256.New()
+	hash.Write([]byte(signingString))
+	resp, err := vault.SignMessage(
+		vaultAccessToken(),
+		j.VaultKey.VaultID.String(),
+		j.VaultKey.ID.String(),
+		hex.EncodeToString(hash.Sum(nil)),
+		map[string]interface{}{
+			"algorithm": jwt.SigningMethodRS256.Alg(),
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT using vault key: %s; %s", j.VaultKey.ID.String(), err.Error())
+	}
+	if resp.Signature == nil {
+		return "", fmt.Errorf("failed to sign JWT using vault key: %s; no signature returned", j.VaultKey.ID.String())
+	}
+
+	sig, err := hex.DecodeString(*resp.Signature)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode JWT signature from vault key: %s; %s", j.VaultKey.ID.String(), err.Error())
+	}
+	return fmt.Sprintf("%s.%s", signingString, base64.RawURLEncoding.EncodeToString(sig)), nil
+}
+
+// ResolveKey returns the public key of the keypair if the given kid is empty or matches its
+// fingerprint, so a keypair may verify the JWTs it signs
+func (j *JWTKeypair) ResolveKey(ctx context.Context, kid string) (interface{}, error) {
+	if kid != "" && kid != j.Fingerprint {
+		return nil, fmt.Errorf("failed to resolve JWT verification key; invalid kid specified in header: %s", kid)
+	}
+	return &j.PublicKey, nil
+}
+
 // PublicKey returns an associated PublicKey instance.
 func (j *JWTKeypair) SSHSigner() ssh.Signer {
 	return &JWTKeypairSSHSigner{
@@ -194,26 +251,45 @@ func AuthorizedSubjectID(c *gin.Context, subject string) *uuid.UUID {
 		common.Log.Tracef("no %s subject claim parsed from bearer authorization header; %s", subject, err.Error())
 		return nil
 	}
-	var id string
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		if sub, subok := claims["sub"].(string); subok {
-			subprts := strings.Split(sub, ":")
-			if len(subprts) != 2 {
-				common.Log.Debugf("failed to parse %s subject claim from bearer authorization header; subject malformed: %s", subject, sub)
-				return nil
-			}
-			if subprts[0] != subject {
-				return nil
-			}
-			id = subprts[1]
-		}
-	}
-	uuidV4, err := uuid.FromString(id)
+
+	claims, err := ParseProvideClaims(token)
 	if err != nil {
-		common.Log.Debugf("failed to parse %s subject from bearer authorization header; %s", subject, err.Error())
+		common.Log.Debugf("failed to parse %s subject claim from bearer authorization header; %s", subject, err.Error())
 		return nil
 	}
-	return &uuidV4
+	if claims.SubjectType() != subject {
+		return nil
+	}
+
+	id := claims.SubjectID()
+	if id == nil {
+		common.Log.Debugf("failed to parse %s subject from bearer authorization header; subject malformed: %s", subject, claims.Subject)
+	}
+	return id
+}
+
+// ParseProvideClaims parses the subject, application claims and NATS claims of the given JWT,
+// which are read from the JWTApplicationClaimsKey and JWTNatsClaimsKey claims when configured
+func ParseProvideClaims(token *jwt.Token) (*ident.ProvideClaims, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("failed to parse provide claims; unexpected JWT claims type")
+	}
+
+	applicationClaimsKey := JWTApplicationClaimsKey
+	if applicationClaimsKey == "" {
+		applicationClaimsKey = ident.ApplicationClaimsKey
+	}
+	natsClaimsKey := JWTNatsClaimsKey
+	if natsClaimsKey == "" {
+		natsClaimsKey = ident.NATSClaimsKey
+	}
+
+	return ident.ParseProvideClaims(map[string]interface{}{
+		"sub":                      claims["sub"],
+		ident.ApplicationClaimsKey: claims[applicationClaimsKey],
+		ident.NATSClaimsKey:        claims[natsClaimsKey],
+	})
 }
 
 // ParseBearerAuthorizationHeader parses a bearer authorization header
@@ -410,14 +486,16 @@ func requireIdentJWTVerifiers() {
 		common.Log.Warningf("failed to resolve ident jwt keys; %s", err.Error())
 	} else {
 		for _, key := range keys {
-			publicKey, err := pgputil.DecodeRSAPublicKeyFromPEM([]byte(key.PublicKey))
+			publicKey, err := key.RSAPublicKey()
 			if err != nil {
 				common.Log.Warningf("failed to parse ident JWT public key; %s", err.Error())
+				continue
 			}
 
 			sshPublicKey, err := ssh.NewPublicKey(publicKey)
 			if err != nil {
 				common.Log.Warningf("failed to resolve JWT public key fingerprint; %s", err.Error())
+				continue
 			}
 			fingerprint := ssh.FingerprintLegacyMD5(sshPublicKey)
 
@@ -426,7 +504,7 @@ func requireIdentJWTVerifiers() {
 			jwtKeypairs[fingerprint] = &JWTKeypair{
 				Fingerprint:  fingerprint,
 				PublicKey:    *publicKey,
-				PublicKeyPEM: &key.PublicKey,
+				PublicKeyPEM: common.StringOrNil(key.PublicKey),
 				SSHPublicKey: &sshPublicKey,
 			}
 
@@ -537,7 +615,7 @@ func requireVaultJWTKeypairs() {
 		select {
 		case <-timer.C:
 			if Vault != nil {
-				keys, err := vault.ListKeys(DefaultVaultAccessJWT, Vault.ID.String(), map[string]interface{}{
+				keys, err := vault.ListKeys(vaultAccessToken(), Vault.ID.String(), map[string]interface{}{
 					"spec": defaultTokenSigningKeyspec,
 				})
 				if err != nil {
@@ -550,7 +628,7 @@ func requireVaultJWTKeypairs() {
 				if len(keys) > 0 {
 					jwtSigningKey = keys[0]
 				} else {
-					jwtSigningKey, err = vault.CreateKey(DefaultVaultAccessJWT, Vault.ID.String(), map[string]interface{}{
+					jwtSigningKey, err = vault.CreateKey(vaultAccessToken(), Vault.ID.String(), map[string]interface{}{
 						"name":        fmt.Sprintf("JWT %s signer", defaultTokenSigningKeyspec),
 						"description": fmt.Sprintf("JWT %s signer", defaultTokenSigningKeyspec),
 						"spec":        defaultTokenSigningKeyspec,
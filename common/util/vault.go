@@ -1,6 +1,7 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -10,17 +11,19 @@ import (
 	common "github.com/provideplatform/provide-go/common"
 )
 
-const refreshTokenTickInterval = 60000 * 45 * time.Millisecond
-const refreshTokenSleepInterval = 60000 * 10 * time.Millisecond
-
 const requireVaultTickerInterval = time.Second * 5
 const requireVaultSleepInterval = time.Second * 1
 const requireVaultTimeout = time.Minute * 1
 
 var (
-	// DefaultVaultAccessJWT for the default vault context
+	// DefaultVaultAccessJWT for the default vault context, as obtained when the vault was
+	// required; it is not refreshed, so DefaultVaultTokenSource should be used for a current token
 	DefaultVaultAccessJWT string
 
+	// DefaultVaultTokenSource provides access tokens for the default vault context, refreshing
+	// them as they near expiration; it is suitable for use as the TokenSource of an api.Client
+	DefaultVaultTokenSource *ident.RefreshTokenSource
+
 	// defaultVaultRefreshJWT for the default vault context
 	defaultVaultRefreshJWT string
 
@@ -42,34 +45,20 @@ func RequireVault() {
 			if ident.Status() == nil {
 				defaultVaultRefreshJWT = os.Getenv("VAULT_REFRESH_TOKEN")
 				if defaultVaultRefreshJWT != "" {
-					accessToken, err := refreshVaultAccessToken()
+					if DefaultVaultTokenSource == nil {
+						DefaultVaultTokenSource = ident.NewRefreshTokenSource(defaultVaultRefreshJWT, nil)
+					}
+					accessToken, err := DefaultVaultTokenSource.Token(context.Background())
 					if err != nil {
 						common.Log.Warningf("failed to refresh vault access token; %s", err.Error())
 						continue
 					}
 
-					DefaultVaultAccessJWT = *accessToken
+					DefaultVaultAccessJWT = accessToken
 					if DefaultVaultAccessJWT == "" {
 						common.Log.Warning("failed to authorize vault access token for environment")
 						continue
 					}
-
-					go func() {
-						timer := time.NewTicker(refreshTokenTickInterval)
-						for {
-							select {
-							case <-timer.C:
-								token, err := refreshVaultAccessToken()
-								if err != nil {
-									common.Log.Warningf("failed to refresh vault access token; %s", err.Error())
-								} else {
-									DefaultVaultAccessJWT = *token
-								}
-							default:
-								time.Sleep(refreshTokenSleepInterval)
-							}
-						}
-					}()
 				}
 
 				defaultVaultSealUnsealKey = os.Getenv("VAULT_SEAL_UNSEAL_KEY")
@@ -83,7 +72,7 @@ func RequireVault() {
 					}
 				}
 
-				vaults, err := vault.ListVaults(DefaultVaultAccessJWT, map[string]interface{}{})
+				vaults, err := vault.ListVaults(vaultAccessToken(), map[string]interface{}{})
 				if err != nil {
 					common.Log.Warningf("failed to fetch vaults for given token; %s", err.Error())
 					continue
@@ -94,7 +83,7 @@ func RequireVault() {
 					Vault = vaults[0]
 					common.Log.Debugf("resolved default vault instance: %s", Vault.ID.String())
 				} else {
-					Vault, err = vault.CreateVault(DefaultVaultAccessJWT, map[string]interface{}{
+					Vault, err = vault.CreateVault(vaultAccessToken(), map[string]interface{}{
 						"name":        fmt.Sprintf("default vault %d", time.Now().Unix()),
 						"description": "default vault instance",
 					})
@@ -119,7 +108,7 @@ func RequireVault() {
 
 // SealVault seals the configured vault context
 func SealVault() error {
-	_, err := vault.Seal(DefaultVaultAccessJWT, map[string]interface{}{
+	_, err := vault.Seal(vaultAccessToken(), map[string]interface{}{
 		"key": defaultVaultSealUnsealKey,
 	})
 
@@ -133,7 +122,7 @@ func SealVault() error {
 
 // UnsealVault unseals the configured vault context
 func UnsealVault() error {
-	_, err := vault.Unseal(common.StringOrNil(DefaultVaultAccessJWT), map[string]interface{}{
+	_, err := vault.Unseal(common.StringOrNil(vaultAccessToken()), map[string]interface{}{
 		"key": defaultVaultSealUnsealKey,
 	})
 
@@ -145,21 +134,17 @@ func UnsealVault() error {
 	return nil
 }
 
-func refreshVaultAccessToken() (*string, error) {
-	token, err := ident.CreateToken(defaultVaultRefreshJWT, map[string]interface{}{
-		"grant_type": "refresh_token",
-	})
-
-	if err != nil {
-		common.Log.Warningf("failed to authorize access token for given vault refresh token; %s", err.Error())
-		return nil, err
+// vaultAccessToken returns a current access token for the default vault context from
+// DefaultVaultTokenSource, or DefaultVaultAccessJWT if the token source is not configured
+func vaultAccessToken() string {
+	if DefaultVaultTokenSource == nil {
+		return DefaultVaultAccessJWT
 	}
 
-	if token.AccessToken == nil {
-		err := fmt.Errorf("failed to authorize access token for given vault refresh token: %s", token.ID.String())
-		common.Log.Warning(err.Error())
-		return nil, err
+	token, err := DefaultVaultTokenSource.Token(context.Background())
+	if err != nil {
+		common.Log.Warningf("failed to refresh vault access token; %s", err.Error())
+		return DefaultVaultAccessJWT
 	}
-
-	return token.AccessToken, nil
+	return token
 }
@@ -4,6 +4,7 @@ go 1.13
 
 require (
 	github.com/aead/ecdh v0.2.0
+	github.com/andybalholm/brotli v1.0.4
 	github.com/btcsuite/btcd v0.21.0-beta
 	github.com/btcsuite/btcutil v1.0.2
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
@@ -0,0 +1,260 @@
+// Package provide aggregates the clients of each Provide service behind a single Client.
+package provide
+
+import (
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/api/baseline"
+	"github.com/provideplatform/provide-go/api/bookie"
+	"github.com/provideplatform/provide-go/api/c2"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/api/nchain"
+	"github.com/provideplatform/provide-go/api/privacy"
+	"github.com/provideplatform/provide-go/api/vault"
+)
+
+// ServiceName identifies a Provide service
+type ServiceName string
+
+const (
+	// ServiceBaseline identifies the baseline api
+	ServiceBaseline ServiceName = "baseline"
+
+	// ServiceBookie identifies the bookie api
+	ServiceBookie ServiceName = "bookie"
+
+	// ServiceC2 identifies the c2 api
+	ServiceC2 ServiceName = "c2"
+
+	// ServiceIdent identifies the ident api
+	ServiceIdent ServiceName = "ident"
+
+	// ServiceNChain identifies the nchain api
+	ServiceNChain ServiceName = "nchain"
+
+	// ServicePrivacy identifies the privacy api
+	ServicePrivacy ServiceName = "privacy"
+
+	// ServiceVault identifies the vault api
+	ServiceVault ServiceName = "vault"
+)
+
+// Client provides access to each Provide service; the services share credentials, retry
+// policy, middleware and a single pooled transport
+type Client struct {
+	baseline *baseline.Service
+	bookie   *bookie.Service
+	c2       *c2.Service
+	ident    *ident.Service
+	nchain   *nchain.Service
+	privacy  *privacy.Service
+	vault    *vault.Service
+}
+
+type options struct {
+	shared   []api.Option
+	services map[ServiceName][]api.Option
+}
+
+// Option configures a Client
+type Option func(*options)
+
+// WithOptions applies the given options to every service
+func WithOptions(opts ...api.Option) Option {
+	return func(o *options) {
+		o.shared = append(o.shared, opts...)
+	}
+}
+
+// WithServiceOptions applies the given options to the named service only; these are applied
+// after the options shared by every service, i.e., to point a service at a specific url
+func WithServiceOptions(service ServiceName, opts ...api.Option) Option {
+	return func(o *options) {
+		o.services[service] = append(o.services[service], opts...)
+	}
+}
+
+// WithToken authorizes requests to every service using the given bearer token
+func WithToken(token string) Option {
+	return WithOptions(api.WithToken(token))
+}
+
+// WithTokenSource authorizes requests to every service using tokens from the given source
+func WithTokenSource(tokenSource api.TokenSource) Option {
+	return WithOptions(api.WithTokenSource(tokenSource))
+}
+
+// New initializes a *Client; each service is configured from its environment, then from
+// the options shared by all services and finally from its service-specific options. The shared
+// options are applied once, so the services share the TLS and transport configurations they
+// build, and therefore a pooled transport.
+func New(opts ...Option) (*Client, error) {
+	o := &options{
+		services: map[ServiceName][]api.Option{},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	shared := &api.Config{}
+	err := shared.Apply(o.shared...)
+	if err != nil {
+		return nil, err
+	}
+
+	configure := func(service ServiceName, config *api.Config) (*api.Config, error) {
+		inherit(config, shared)
+
+		err := config.Apply(o.services[service]...)
+		if err != nil {
+			return nil, err
+		}
+
+		return config, nil
+	}
+
+	c := &Client{}
+
+	config, err := configure(ServiceBaseline, baseline.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.baseline = baseline.NewServiceWithConfig(config)
+
+	config, err = configure(ServiceBookie, bookie.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.bookie = bookie.NewServiceWithConfig(config)
+
+	config, err = configure(ServiceC2, c2.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.c2 = c2.NewServiceWithConfig(config)
+
+	config, err = configure(ServiceIdent, ident.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.ident = ident.NewServiceWithConfig(config)
+
+	config, err = configure(ServiceNChain, nchain.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.nchain = nchain.NewServiceWithConfig(config)
+
+	config, err = configure(ServicePrivacy, privacy.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.privacy = privacy.NewServiceWithConfig(config)
+
+	config, err = configure(ServiceVault, vault.ConfigFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	c.vault = vault.NewServiceWithConfig(config)
+
+	return c, nil
+}
+
+// inherit copies each field set on the shared config to the config of a service; the service
+// name is never inherited
+func inherit(config, shared *api.Config) {
+	if shared.Host != "" {
+		config.Host = shared.Host
+	}
+	if shared.Path != "" {
+		config.Path = shared.Path
+	}
+	if shared.Scheme != "" {
+		config.Scheme = shared.Scheme
+	}
+	if shared.Token != nil {
+		config.Token = shared.Token
+	}
+	if shared.TokenSource != nil {
+		config.TokenSource = shared.TokenSource
+	}
+	if shared.Username != nil {
+		config.Username = shared.Username
+	}
+	if shared.Password != nil {
+		config.Password = shared.Password
+	}
+	if shared.UserAgent != nil {
+		config.UserAgent = shared.UserAgent
+	}
+	if shared.Timeout != 0 {
+		config.Timeout = shared.Timeout
+	}
+	if shared.TLSClientConfig != nil {
+		config.TLSClientConfig = shared.TLSClientConfig
+	}
+	if shared.HTTPClient != nil {
+		config.HTTPClient = shared.HTTPClient
+	}
+	if len(shared.Middleware) > 0 {
+		config.Middleware = append(shared.Middleware[:len(shared.Middleware):len(shared.Middleware)], config.Middleware...)
+	}
+	if shared.RetryPolicy != nil {
+		config.RetryPolicy = shared.RetryPolicy
+	}
+	if shared.TransportConfig != nil {
+		config.TransportConfig = shared.TransportConfig
+	}
+	if shared.MaxResponseSize != 0 {
+		config.MaxResponseSize = shared.MaxResponseSize
+	}
+	if shared.RateLimiter != nil {
+		config.RateLimiter = shared.RateLimiter
+	}
+	if shared.CircuitBreaker != nil {
+		config.CircuitBreaker = shared.CircuitBreaker
+	}
+	if shared.Cache != nil {
+		config.Cache = shared.Cache
+	}
+	if shared.Tracer != nil {
+		config.Tracer = shared.Tracer
+	}
+	if shared.Metrics != nil {
+		config.Metrics = shared.Metrics
+	}
+}
+
+// Baseline returns the baseline service
+func (c *Client) Baseline() *baseline.Service {
+	return c.baseline
+}
+
+// Bookie returns the bookie service
+func (c *Client) Bookie() *bookie.Service {
+	return c.bookie
+}
+
+// C2 returns the c2 service
+func (c *Client) C2() *c2.Service {
+	return c.c2
+}
+
+// Ident returns the ident service
+func (c *Client) Ident() *ident.Service {
+	return c.ident
+}
+
+// NChain returns the nchain service
+func (c *Client) NChain() *nchain.Service {
+	return c.nchain
+}
+
+// Privacy returns the privacy service
+func (c *Client) Privacy() *privacy.Service {
+	return c.privacy
+}
+
+// Vault returns the vault service
+func (c *Client) Vault() *vault.Service {
+	return c.vault
+}
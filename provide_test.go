@@ -0,0 +1,45 @@
+package provide
+
+import (
+	"testing"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+func TestServicesShareTransportAndCredentials(t *testing.T) {
+	client, err := New(
+		WithToken("s3cr3t"),
+		WithOptions(
+			api.WithPinnedPublicKeys("sha256/YLh1dUR9y6Kja30RrAn7JKnbQG/uEtLMkBgFF2Fuihg="),
+			api.WithProxy("http://proxy.example.com:3128"),
+		),
+		WithServiceOptions(ServiceVault, api.WithURL("https://vault.example.com/api/v1")),
+	)
+	if err != nil {
+		t.Fatalf("failed to initialize client; %s", err.Error())
+	}
+
+	ident := client.Ident().Client
+	for name, service := range map[ServiceName]api.Client{
+		ServiceBaseline: client.Baseline().Client,
+		ServiceBookie:   client.Bookie().Client,
+		ServiceC2:       client.C2().Client,
+		ServiceNChain:   client.NChain().Client,
+		ServicePrivacy:  client.Privacy().Client,
+		ServiceVault:    client.Vault().Client,
+	} {
+		if service.TLSClientConfig == nil || service.TLSClientConfig != ident.TLSClientConfig {
+			t.Errorf("expected %s to share the TLS configuration of ident", name)
+		}
+		if service.TransportConfig == nil || service.TransportConfig != ident.TransportConfig {
+			t.Errorf("expected %s to share the transport configuration of ident", name)
+		}
+		if service.Token == nil || *service.Token != "s3cr3t" {
+			t.Errorf("expected %s to share the token", name)
+		}
+	}
+
+	if vault := client.Vault().Client; vault.Host != "vault.example.com" || ident.Host == vault.Host {
+		t.Errorf("expected service options to apply to vault only; got %s and %s", ident.Host, vault.Host)
+	}
+}